@@ -1,51 +1,52 @@
 package localization
 
 import (
-	"github.com/spf13/cast"
-	"regexp"
+	"iter"
 	"sort"
+	"strconv"
 	"strings"
 )
 
-// regex for finding languages groups with or without priority, for example:
-// "en-US,en;q=0.5"
-var regexLanguagesByWeight = regexp.MustCompile(`(((([\*]{1})|(([a-z]{2}))+(-)+([a-zA-Z]{2})|([a-z]{2}))+(,?)+( ?)){1,})+(;?)+((q=[0-9\.]{1,})?)`)
+// maxAcceptLanguageLength is maximum length of Accept-Language value, longer
+// values are ignored to protect against abusive headers.
+const maxAcceptLanguageLength = 4096
 
-// regexWeight is for finding weight/priority, for example: "q=0.5"
-var regexWeight = regexp.MustCompile(`(q=[0-9\.]{1,})`)
+// maxAcceptLanguageEntries is maximum count of parsed Accept-Language
+// entries, following entries are ignored.
+const maxAcceptLanguageEntries = 32
 
-// regexLang is used for finding languages, for example: "*", "en-US", "en", "lv"
-var regexLang = regexp.MustCompile(`(([\*]{1})|([a-z]{2})+(-)+([A-Z]{2})|([a-z]{2}))`)
-
-// PriorityGroup holds information about specific Language priority
-// group.
-// Each group contains specific weight (if defined) and list of languages
-// inside in that priority.
-type PriorityGroup struct {
-	Weight    float32  // Language group weight/priority
-	Languages []string // Slice of languages in current priority group.
+// AcceptEntry holds single Accept-Language entry.
+type AcceptEntry struct {
+	Range   string  // Language range ("en-US", "*").
+	Quality float32 // Quality value (weight), 1 if not present in header.
 }
 
 // AcceptLanguages is used to read and contain information about request
 // header Accept-Language data.
 // To parse Request header Accept-Language use localization.ParseAcceptLanguage
 // which will return AcceptLanguages structure with parsed data.
+// AcceptLanguages is not modified by matching methods, so it can be reused.
 type AcceptLanguages struct {
-	LangGroups []PriorityGroup // For each weight 1 language group
+	entries []AcceptEntry // Entries ordered by quality (1 -> 0), then by header order.
 }
 
 // ParseAcceptLanguage can be used to parsed passed request header Accept-Language
 // into AcceptLanguage structure.
 // Value MUST BE Accept-Language value.
-// Returns pointer to AcceptLanguages structure or error.
+// Value is parsed by RFC 9110 rules: comma separated language ranges ("en",
+// "zh-Hant-TW", "es-419", "*") with optional quality value ("q=0.5", defaults
+// to 1). Not valid entries are skipped. Values longer than 4096 bytes are
+// ignored and only the first 32 entries are parsed.
+// Returns pointer to AcceptLanguages structure.
 //
 // Information about Accept-Language ...
 // Docs:
+// https://www.rfc-editor.org/rfc/rfc9110#section-12.5.4
 // https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Accept-Language
 // Some examples:
 // https://www.holisticseo.digital/technical-seo/http-header/content-negotiation/accept-language/
 func ParseAcceptLanguage(value string) *AcceptLanguages {
-	if value == "" {
+	if value == "" || len(value) > maxAcceptLanguageLength {
 		// Valid pointer is required for later usage (calling
 		// method without panicking).
 		return &AcceptLanguages{}
@@ -53,101 +54,383 @@ func ParseAcceptLanguage(value string) *AcceptLanguages {
 
 	acceptLangs := AcceptLanguages{}
 
-	// Split languages by weight.
+	// Split value into entries.
 	acceptLangs.splitByWeight(value)
 
 	// Order parsed accept-languages by provided weights.
-	if len(acceptLangs.LangGroups) > 0 {
+	if len(acceptLangs.entries) > 0 {
 		acceptLangs.orderByWeight()
 	}
 
 	return &acceptLangs
 }
 
+// LanguageMatch holds information about enabled language matching
+// Accept-Language range.
+type LanguageMatch struct {
+	Language string  // Enabled language keyword.
+	Range    string  // Accept-Language range language matched ("en-US", "*").
+	Quality  float32 // Range quality value (weight).
+}
+
 // FindFirstMatchingLang can be used to extract first matching language
 // in AcceptLanguages by proving prioritized enabled languages slice.
-// If AcceptLanguage contains '*' (asterisk) then it will be replaced with
-// passed default language.
+// Languages are matched by RFC 4647 lookup (see AcceptLanguages.Lookup).
+// If AcceptLanguage contains '*' (asterisk) then it will be matched with
+// passed default language or with the first enabled language not listed in
+// other ranges.
 // Returns first matching language key or empty string on no match.
 //
 // Params:
 // enabledLanguages - prioritized slice of enabled languages.
-// defaultLang - default language which will be used in asterisk cases.
+// defaultLang - preferred language in asterisk cases (optional).
 func (al *AcceptLanguages) FindFirstMatchingLang(enabledLanguages []string, defaultLang string) string {
-	tempLangGroups := al.replaceAsterisks(defaultLang)
+	matches := al.Lookup(enabledLanguages, defaultLang)
+	if len(matches) == 0 {
+		return ""
+	}
+
+	return matches[0].Language
+}
+
+// Lookup can be used to match enabled languages by RFC 4647 lookup: each
+// range, in order of quality, gets progressively truncated until it matches
+// enabled language ("de-CH-1996" -> "de-CH" -> "de"). Ranges with q=0
+// exclude matching languages, unless more specific range allows them.
+// '*' (asterisk) matches any enabled language not listed in other ranges or
+// excluded with q=0, passed default language first.
+// AcceptLanguages is not modified, so it can be matched multiple times.
+// Returns enabled languages ranked by quality (best first), each language
+// at most once, or empty slice if none match.
+//
+// Docs:
+// https://www.rfc-editor.org/rfc/rfc4647#section-3.4
+//
+// Params:
+// enabledLanguages - prioritized slice of enabled languages.
+// defaultLang - preferred language in asterisk cases (optional).
+func (al *AcceptLanguages) Lookup(enabledLanguages []string, defaultLang string) []LanguageMatch {
+	ranges := al.entries
+	matches := make([]LanguageMatch, 0)
+
+	for _, v := range ranges {
+		if v.Quality <= 0 {
+			continue
+		}
 
-	for _, v := range tempLangGroups {
-		for _, y := range v.Languages {
-			for _, z := range enabledLanguages {
-				if strings.EqualFold(y, z) {
-					return z
+		if v.Range == "*" {
+			for _, lang := range wildcardLanguages(ranges, enabledLanguages, defaultLang) {
+				if !containsMatch(matches, lang) {
+					matches = append(matches, LanguageMatch{lang, v.Range, v.Quality})
 				}
 			}
+
+			continue
+		}
+
+		for candidate := v.Range; candidate != ""; candidate = truncateRange(candidate) {
+			lang, exist := findLanguage(enabledLanguages, candidate)
+			if !exist || isExcluded(ranges, lang) {
+				continue
+			}
+
+			if !containsMatch(matches, lang) {
+				matches = append(matches, LanguageMatch{lang, v.Range, v.Quality})
+			}
+
+			break
 		}
 	}
 
-	return ""
+	return matches
 }
 
-// splitByWeight split given Accept-Language string by weight.
-// Returns error if something went wrong.
-func (al *AcceptLanguages) splitByWeight(value string) {
-	al.LangGroups = make([]PriorityGroup, 0)
+// Filter can be used to match enabled languages by RFC 4647 basic filtering:
+// range matches language if it is equal to language or its prefix ("en"
+// matches "en" and "en-GB"), '*' (asterisk) matches all languages. Each
+// language gets quality of the most specific matching range, languages with
+// quality 0 (q=0) are excluded.
+// Returns matching enabled languages ranked by quality (best first), then
+// by range order, or empty slice if none match.
+//
+// Docs:
+// https://www.rfc-editor.org/rfc/rfc4647#section-3.3.1
+//
+// Params:
+// enabledLanguages - prioritized slice of enabled languages.
+func (al *AcceptLanguages) Filter(enabledLanguages []string) []LanguageMatch {
+	ranges := al.entries
+	matches := make([]LanguageMatch, 0)
+	order := make(map[string]int)
+
+	for _, lang := range enabledLanguages {
+		idx, exist := bestRange(ranges, lang)
+		if !exist || ranges[idx].Quality <= 0 || containsMatch(matches, lang) {
+			continue
+		}
+
+		matches = append(matches, LanguageMatch{lang, ranges[idx].Range, ranges[idx].Quality})
+		order[lang] = idx
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Quality != matches[j].Quality {
+			return matches[i].Quality > matches[j].Quality
+		}
+
+		return order[matches[i].Language] < order[matches[j].Language]
+	})
+
+	return matches
+}
+
+// Entries returns iterator over parsed entries ordered by quality (best
+// first). Entries with equal quality keep header order. Can be used for
+// custom negotiation:
+//
+//	for entry := range accept.Entries() {
+//		// entry.Range, entry.Quality
+//	}
+func (al *AcceptLanguages) Entries() iter.Seq[AcceptEntry] {
+	return func(yield func(AcceptEntry) bool) {
+		for _, v := range al.entries {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns count of parsed entries.
+func (al *AcceptLanguages) Len() int {
+	return len(al.entries)
+}
+
+// wildcardLanguages returns enabled languages matched by '*' (asterisk):
+// languages not listed in other ranges (including q=0 exclusions), default
+// language first.
+func wildcardLanguages(ranges []AcceptEntry, enabledLanguages []string, defaultLang string) []string {
+	langs := make([]string, 0, len(enabledLanguages))
+
+	lang, exist := findLanguage(enabledLanguages, defaultLang)
+	if exist && !isListed(ranges, lang) {
+		langs = append(langs, lang)
+	}
+
+	for _, v := range enabledLanguages {
+		if v != lang && !isListed(ranges, v) {
+			langs = append(langs, v)
+		}
+	}
+
+	return langs
+}
+
+// isListed checks if language is matched by any range other than '*'
+// (asterisk) by basic filtering ("en" matches "en-GB") or lookup ("en-GB"
+// matches "en").
+func isListed(ranges []AcceptEntry, lang string) bool {
+	for _, v := range ranges {
+		if v.Range != "*" && (matchesRange(v.Range, lang) || matchesRange(lang, v.Range)) {
+			return true
+		}
+	}
+
+	return false
+}
 
-	dataSlices := regexLanguagesByWeight.FindAll([]byte(value), -1)
+// bestRange returns index of the most specific range matching language by
+// basic filtering. '*' (asterisk) range is used only if no other range matches.
+// Returns false if none of ranges match.
+func bestRange(ranges []AcceptEntry, lang string) (int, bool) {
+	best, wildcard := -1, -1
 
-	for _, v := range dataSlices {
-		langList := PriorityGroup{}
+	for k, v := range ranges {
+		if v.Range == "*" {
+			if wildcard < 0 {
+				wildcard = k
+			}
+
+			continue
+		}
+
+		if !matchesRange(v.Range, lang) {
+			continue
+		}
+
+		if best < 0 || len(v.Range) > len(ranges[best].Range) {
+			best = k
+		}
+	}
+
+	if best < 0 {
+		best = wildcard
+	}
+
+	return best, best >= 0
+}
+
+// isExcluded checks if language is excluded by q=0 range, which is the most
+// specific range matching language (see bestRange). Language excluded only by
+// '*' (asterisk) range is not reported, because asterisk applies only to not
+// listed languages.
+func isExcluded(ranges []AcceptEntry, lang string) bool {
+	idx, exist := bestRange(ranges, lang)
+	return exist && ranges[idx].Range != "*" && ranges[idx].Quality <= 0
+}
+
+// matchesRange checks if language range matches language by basic filtering
+// (case-insensitive).
+func matchesRange(languageRange, lang string) bool {
+	if len(languageRange) > len(lang) || !strings.EqualFold(languageRange, lang[:len(languageRange)]) {
+		return false
+	}
+
+	return len(languageRange) == len(lang) || lang[len(languageRange)] == '-'
+}
+
+// truncateRange removes the last subtag from language range, including
+// preceding single-character subtag ("zh-Hant-CN-x-a" -> "zh-Hant-CN").
+// Returns empty string if range has no more subtags to remove.
+func truncateRange(languageRange string) string {
+	end := strings.LastIndexByte(languageRange, '-')
+	if end < 0 {
+		return ""
+	}
+
+	languageRange = languageRange[:end]
 
-		langList.parse(v)
-		al.LangGroups = append(al.LangGroups, langList)
+	end = strings.LastIndexByte(languageRange, '-')
+	if end >= 0 && end == len(languageRange)-2 {
+		languageRange = languageRange[:end]
 	}
+
+	return languageRange
+}
+
+// findLanguage returns enabled language matching passed value (case-insensitive).
+// Returns false if language is not enabled.
+func findLanguage(enabledLanguages []string, value string) (string, bool) {
+	if value == "" {
+		return "", false
+	}
+
+	for _, v := range enabledLanguages {
+		if strings.EqualFold(v, value) {
+			return v, true
+		}
+	}
+
+	return "", false
 }
 
-// orderByWeight order generated PriorityGroups by weight (1 -> 0).
+// containsMatch checks if matches already contain language.
+func containsMatch(matches []LanguageMatch, lang string) bool {
+	for _, v := range matches {
+		if v.Language == lang {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitByWeight splits given Accept-Language value into entries.
+func (al *AcceptLanguages) splitByWeight(value string) {
+	for _, entry := range strings.Split(value, ",") {
+		if len(al.entries) >= maxAcceptLanguageEntries {
+			return
+		}
+
+		languageRange, weight, ok := parseAcceptEntry(entry)
+		if !ok {
+			continue
+		}
+
+		al.entries = append(al.entries, AcceptEntry{languageRange, weight})
+	}
+}
+
+// orderByWeight orders entries by weight (1 -> 0). Entries with equal weight
+// keep header order.
 func (al *AcceptLanguages) orderByWeight() {
-	sort.Slice(al.LangGroups, func(i, j int) bool {
-		return al.LangGroups[i].Weight > al.LangGroups[j].Weight
+	sort.SliceStable(al.entries, func(i, j int) bool {
+		return al.entries[i].Quality > al.entries[j].Quality
 	})
 }
 
-// parse is used to parse given priority group string into
-// actual PriorityGroup structure/values.
-func (l *PriorityGroup) parse(value []byte) {
-	l.extractWeight(value)
+// parseAcceptEntry parses single Accept-Language entry ("en-US;q=0.5").
+// Returns language range, weight (1 if not present) or false if entry is
+// empty or not valid.
+func parseAcceptEntry(entry string) (string, float32, bool) {
+	languageRange, params, hasParams := strings.Cut(entry, ";")
 
-	langs := regexLang.FindAll(value, -1)
+	languageRange = strings.Trim(languageRange, " \t")
+	if !isLanguageRange(languageRange) {
+		return "", 0, false
+	}
 
-	for _, v := range langs {
-		l.Languages = append(l.Languages, string(v))
+	if !hasParams {
+		return languageRange, 1, true
 	}
+
+	name, qvalue, ok := strings.Cut(strings.Trim(params, " \t"), "=")
+	if !ok || (name != "q" && name != "Q") {
+		return "", 0, false
+	}
+
+	weight, ok := parseQValue(qvalue)
+	if !ok {
+		return "", 0, false
+	}
+
+	return languageRange, weight, true
 }
 
-// replaceAsterisks is used to replace all asterisks inside AcceptLanguages
-// structure with provided default language.
-// Returns modified slice of PriorityGroup.
-func (al *AcceptLanguages) replaceAsterisks(defaultLang string) []PriorityGroup {
-	langGroups := make([]PriorityGroup, len(al.LangGroups))
+// isLanguageRange checks if value is valid language range: "*" or 1-8
+// letters followed by subtags of 1-8 letters or digits ("en", "zh-Hant-TW").
+func isLanguageRange(value string) bool {
+	if value == "*" {
+		return true
+	}
+
+	first := true
 
-	for k, v := range al.LangGroups {
-		langGroups[k] = v
+	for _, subtag := range strings.Split(value, "-") {
+		if len(subtag) == 0 || len(subtag) > 8 {
+			return false
+		}
 
-		for x, y := range v.Languages {
-			langGroups[k].Languages[x] = strings.ReplaceAll(y, "*", defaultLang)
+		if (first && !isAlpha(subtag)) || !isAlphaNum(subtag) {
+			return false
 		}
+
+		first = false
 	}
 
-	return langGroups
+	return true
 }
 
-// extractWeight is used to extract current PriorityGroup weight from
-// passed value bytes.
-func (l *PriorityGroup) extractWeight(value []byte) {
-	weight := string(regexWeight.Find(value))
-	if weight == "" {
-		return
+// parseQValue parses quality value: "0" with up to 3 decimals or "1" with
+// up to 3 zero decimals ("0.5", "1.000").
+// Returns false if value is not valid quality value.
+func parseQValue(value string) (float32, bool) {
+	if len(value) == 0 || len(value) > 5 || (value[0] != '0' && value[0] != '1') {
+		return 0, false
+	}
+
+	if len(value) > 1 && (value[1] != '.' || !isDigit(value[2:])) {
+		return 0, false
+	}
+
+	if value[0] == '1' {
+		return 1, strings.Trim(value[1:], ".0") == ""
+	}
+
+	weight, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return 0, false
 	}
 
-	weight = strings.Replace(weight, "q=", "", 1)
-	l.Weight = cast.ToFloat32(weight)
+	return float32(weight), true
 }
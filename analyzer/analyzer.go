@@ -0,0 +1,270 @@
+// Package analyzer provides go/analysis Analyzer, which checks calls of
+// github.com/gaigals/localization translation functions and methods with
+// literal keys (Locale.Value, Text, Textf, Localizer.Tf etc) against
+// translation catalog (YAML files):
+//
+//   - key must exist in all catalog languages (language itself or its parent
+//     languages, see Locale.MissingLanguages);
+//   - fmt verbs of each language value must match count and types of call
+//     site arguments.
+//
+// Use it with go vet (see cmd/localizationvet):
+//
+//	go vet -vettool=$(which localizationvet) -catalog="$PWD/locales/*.yml" ./...
+package analyzer
+
+import (
+	"fmt"
+	"github.com/gaigals/localization"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"strings"
+	"sync"
+)
+
+// localizationPath is import path of checked package.
+const localizationPath = "github.com/gaigals/localization"
+
+// Analyzer checks translation calls against catalog set with -catalog flag
+// (comma separated YAML file paths or patterns) and -default-lang flag
+// (default language for non-list values, "en" by default).
+var Analyzer = New(nil)
+
+// callSpec describes translation function or method arguments.
+type callSpec struct {
+	key      int  // Key argument index.
+	args     int  // First format argument index, -1 if call does not format translation.
+	plural   bool // Plural value can be used.
+	optional bool // Translation is formatted only if format arguments are passed.
+}
+
+// callSpecs maps functions and methods ("Text", "Locale.Value") to their specs.
+var callSpecs = map[string]callSpec{
+	"Text":                    {key: 2, args: -1},
+	"TextPlural":              {key: 2, args: -1, plural: true},
+	"Textf":                   {key: 2, args: 3},
+	"TextPluralf":             {key: 2, args: 4, plural: true},
+	"TextPluralIntf":          {key: 2, args: 3, plural: true},
+	"TextCtx":                 {key: 1, args: 2, optional: true},
+	"TextPluralCtx":           {key: 1, args: 2, plural: true},
+	"TextNamedCtx":            {key: 1, args: -1},
+	"Locale.Value":            {key: 1, args: -1},
+	"Locale.ValueNoErr":       {key: 1, args: -1},
+	"Locale.ValuePlural":      {key: 1, args: -1, plural: true},
+	"Locale.ValuePluralNoErr": {key: 1, args: -1, plural: true},
+	"Locale.Lookup":           {key: 1, args: -1},
+	"Locale.LookupPlural":     {key: 1, args: -1, plural: true},
+	"Localizer.Value":         {key: 0, args: -1},
+	"Localizer.ValuePlural":   {key: 0, args: -1, plural: true},
+	"Localizer.Lookup":        {key: 0, args: -1},
+	"Localizer.T":             {key: 0, args: -1},
+	"Localizer.Tf":            {key: 0, args: 1},
+	"Localizer.TN":            {key: 0, args: 1, plural: true},
+	"Localizer.TNamed":        {key: 0, args: -1},
+	"Localizer.TNNamed":       {key: 0, args: -1, plural: true},
+	"Localizer.HTML":          {key: 0, args: 1, optional: true},
+	"Localizer.HTMLNamed":     {key: 0, args: -1},
+	"Localizer.Rich":          {key: 0, args: -1},
+	"Localizer.RichHTML":      {key: 0, args: -1},
+}
+
+// checker holds catalog used by Analyzer.
+type checker struct {
+	catalog     string // Catalog file patterns (-catalog flag).
+	defaultLang string // Default language (-default-lang flag).
+
+	once   sync.Once
+	locale *localization.Locale
+	err    error
+}
+
+// New constructs Analyzer which checks translation calls against passed
+// Locale. If locale is nil, then catalog is loaded from -catalog flag
+// (see localization.LoadCatalog).
+func New(locale *localization.Locale) *analysis.Analyzer {
+	c := &checker{locale: locale}
+
+	analyzer := &analysis.Analyzer{
+		Name:     "localization",
+		Doc:      "check translation keys and format arguments of localization calls",
+		URL:      "https://pkg.go.dev/github.com/gaigals/localization/analyzer",
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Run:      c.run,
+	}
+
+	if locale == nil {
+		analyzer.Flags.StringVar(&c.catalog, "catalog", "", "comma separated translation YAML file paths or patterns")
+		analyzer.Flags.StringVar(&c.defaultLang, "default-lang", "en", "default language for non-list YAML values")
+	}
+
+	return analyzer
+}
+
+// load returns catalog Locale, catalog is loaded once.
+func (c *checker) load() (*localization.Locale, error) {
+	c.once.Do(func() {
+		if c.locale != nil {
+			return
+		}
+
+		if c.catalog == "" {
+			c.err = fmt.Errorf("translation catalog is not set (-catalog flag)")
+			return
+		}
+
+		c.locale, c.err = localization.LoadCatalog(c.defaultLang, strings.Split(c.catalog, ",")...)
+	})
+
+	return c.locale, c.err
+}
+
+// run checks translation calls of package.
+func (c *checker) run(pass *analysis.Pass) (interface{}, error) {
+	locale, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node) {
+		call := node.(*ast.CallExpr)
+
+		spec, exist := callSpecs[calleeName(pass.TypesInfo, call)]
+		if !exist || spec.key >= len(call.Args) {
+			return
+		}
+
+		key, ok := constantString(pass.TypesInfo, call.Args[spec.key])
+		if !ok {
+			return
+		}
+
+		missing := locale.MissingLanguages(key)
+		if len(missing) > 0 {
+			pass.Reportf(call.Args[spec.key].Pos(), "translation key %q missing for languages: %s",
+				key, strings.Join(missing, ", "))
+		}
+
+		if spec.args < 0 || call.Ellipsis.IsValid() || (spec.optional && len(call.Args) <= spec.args) {
+			return
+		}
+
+		checkFormat(pass, locale, call, key, spec)
+	})
+
+	return nil, nil
+}
+
+// checkFormat reports translation values of key, which fmt verbs do not
+// match call format arguments.
+func checkFormat(pass *analysis.Pass, locale *localization.Locale, call *ast.CallExpr, key string, spec callSpec) {
+	args := call.Args[spec.args:]
+
+	for _, language := range locale.Languages {
+		translation, exist := language.Map[key]
+		if !exist {
+			continue
+		}
+
+		values := translation[:1]
+		if spec.plural {
+			values = translation[:]
+		}
+
+		for k, value := range values {
+			if k == 1 && value == "" {
+				// Plural value is not set.
+				continue
+			}
+
+			name := language.Keyword
+			if k == 1 {
+				name += ", plural"
+			}
+
+			problem := formatProblem(pass, value, args)
+			if problem != "" {
+				pass.Reportf(call.Lparen, "translation %q (%s) %s", key, name, problem)
+			}
+		}
+	}
+}
+
+// formatProblem checks fmt verbs of translation value against arguments.
+// Returns problem description or empty string if value matches arguments.
+func formatProblem(pass *analysis.Pass, value string, args []ast.Expr) string {
+	verbs, err := localization.ParseFormatVerbs(value)
+	if err != nil {
+		return err.Error()
+	}
+
+	// Count is the highest referenced argument index, not count of verbs
+	// ("%[1]s %[1]s" uses 1 arg). fmt does not report extra args of formats
+	// with explicit indexes, so only missing args are reported for them.
+	count := localization.FormatArgCount(verbs)
+	if count > len(args) || (count < len(args) && !hasArgIndex(verbs)) {
+		return fmt.Sprintf("expects %d args, got %d", count, len(args))
+	}
+
+	for _, v := range verbs {
+		typ := pass.TypesInfo.TypeOf(args[v.Arg])
+		if typ != nil && !matchesVerb(v.Verb, typ) {
+			return fmt.Sprintf("verb %s does not match arg %d of type %s", v.Text, v.Arg+1, typ)
+		}
+	}
+
+	return ""
+}
+
+// hasArgIndex checks if any of verbs has explicit argument index ("%[2]s").
+func hasArgIndex(verbs []localization.FormatVerb) bool {
+	for _, v := range verbs {
+		if strings.Contains(v.Text, "[") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// calleeName returns name of called localization function ("Text") or method
+// ("Locale.Value"), empty string for other calls.
+func calleeName(info *types.Info, call *ast.CallExpr) string {
+	function, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || function.Pkg() == nil || function.Pkg().Path() != localizationPath {
+		return ""
+	}
+
+	recv := function.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return function.Name()
+	}
+
+	typ := recv.Type()
+	if pointer, ok := typ.(*types.Pointer); ok {
+		typ = pointer.Elem()
+	}
+
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return ""
+	}
+
+	return named.Obj().Name() + "." + function.Name()
+}
+
+// constantString returns value of string constant expression.
+func constantString(info *types.Info, expr ast.Expr) (string, bool) {
+	value := info.Types[expr].Value
+	if value == nil || value.Kind() != constant.String {
+		return "", false
+	}
+
+	return constant.StringVal(value), true
+}
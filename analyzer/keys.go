@@ -0,0 +1,85 @@
+package analyzer
+
+import (
+	"fmt"
+	"github.com/gaigals/localization"
+	"go/ast"
+	"golang.org/x/tools/go/packages"
+	"sort"
+)
+
+// Keys can be used to find literal or constant translation keys passed to
+// github.com/gaigals/localization functions and methods (the same calls
+// Analyzer checks) in Go packages. Test files are not scanned.
+// Returns key usages sorted by file and position or error if packages can not
+// be loaded.
+// Params:
+// dir - directory to load packages from (current directory if empty).
+// patterns - package patterns ("./...").
+func Keys(dir string, patterns ...string) ([]localization.KeyRef, error) {
+	config := packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes |
+			packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Dir: dir,
+	}
+
+	pkgs, err := packages.Load(&config, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+
+	refs := make([]localization.KeyRef, 0)
+
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil, fmt.Errorf("failed to load package %s: %v", pkg.PkgPath, pkg.Errors[0])
+		}
+
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+
+				name := calleeName(pkg.TypesInfo, call)
+
+				spec, exist := callSpecs[name]
+				if !exist || spec.key >= len(call.Args) {
+					return true
+				}
+
+				key, ok := constantString(pkg.TypesInfo, call.Args[spec.key])
+				if !ok {
+					return true
+				}
+
+				position := pkg.Fset.Position(call.Args[spec.key].Pos())
+
+				refs = append(refs, localization.KeyRef{
+					Key:    key,
+					Func:   name,
+					File:   position.Filename,
+					Line:   position.Line,
+					Column: position.Column,
+				})
+
+				return true
+			})
+		}
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		if refs[i].File != refs[j].File {
+			return refs[i].File < refs[j].File
+		}
+
+		if refs[i].Line != refs[j].Line {
+			return refs[i].Line < refs[j].Line
+		}
+
+		return refs[i].Column < refs[j].Column
+	})
+
+	return refs, nil
+}
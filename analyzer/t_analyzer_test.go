@@ -0,0 +1,91 @@
+package analyzer
+
+import (
+	"github.com/gaigals/localization"
+	"go/types"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"path/filepath"
+	"testing"
+)
+
+func TestAnalyzer(t *testing.T) {
+	locale, _ := localization.NewLocale(false, "en", "lv")
+	locale.SetValueNoErr("en", "title", "Title", "")
+	locale.SetValueNoErr("lv", "title", "Virsraksts", "")
+	locale.SetValueNoErr("en", "only_en", "Only", "")
+	locale.SetValueNoErr("en", "hello", "Hello, %s!", "")
+	locale.SetValueNoErr("lv", "hello", "Sveiki, %s!", "")
+	locale.SetValueNoErr("en", "items", "%d item", "%d items")
+	locale.SetValueNoErr("lv", "items", "%d lieta", "%d lietas")
+	locale.SetValueNoErr("en", "bad_items", "%d item", "%d items")
+	locale.SetValueNoErr("lv", "bad_items", "%d lieta", "%s lietas")
+	locale.SetValueNoErr("en", "price", "%.2f %s", "")
+	locale.SetValueNoErr("lv", "price", "%[2]s %.2[1]f", "")
+	locale.SetValueNoErr("en", "twice", "%[1]s, %[1]s!", "")
+	locale.SetValueNoErr("lv", "twice", "%[2]s, %[1]s!", "")
+
+	analysistest.Run(t, analysistest.TestData(), New(locale), "a")
+}
+
+func TestMatchesVerb(t *testing.T) {
+	testCases := []struct {
+		verb     rune
+		typ      types.Type
+		expected bool
+	}{
+		{'d', types.Typ[types.Int], true},
+		{'d', types.Typ[types.String], false},
+		{'s', types.Typ[types.String], true},
+		{'x', types.Typ[types.String], true},
+		{'f', types.Typ[types.Float64], true},
+		{'f', types.Typ[types.Int], false},
+		{'t', types.Typ[types.Bool], true},
+		{'d', types.Typ[types.Bool], false},
+		{'v', types.Typ[types.Bool], true},
+		{'s', types.NewSlice(types.Typ[types.Byte]), true},
+		{'d', types.NewSlice(types.Typ[types.Int]), true},
+		{'s', types.NewInterfaceType(nil, nil), true},
+		{'p', types.NewPointer(types.Typ[types.Int]), true},
+		{'s', types.NewPointer(types.Typ[types.Int]), false},
+		{'*', types.Typ[types.Int], true},
+		{'*', types.Typ[types.String], false},
+	}
+
+	for k, v := range testCases {
+		received := matchesVerb(v.verb, v.typ)
+		if received != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%t, actual=%t", k, v.expected, received)
+		}
+	}
+}
+
+func TestKeys(t *testing.T) {
+	testdata, _ := filepath.Abs(analysistest.TestData())
+
+	t.Setenv("GOPATH", testdata)
+	t.Setenv("GO111MODULE", "off")
+	t.Setenv("GOFLAGS", "")
+
+	refs, err := Keys(filepath.Join(testdata, "src", "a"), ".")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{
+		"a.go:17:42: Text \"title\"",
+		"a.go:18:42: Text \"only_en\"",
+		"a.go:19:28: Locale.Value \"nowhere\"",
+		"a.go:22:43: Textf \"hello\"",
+	}
+
+	if len(refs) != 19 {
+		t.Fatalf("unexpected key count, expected=19, actual=%d (%v)", len(refs), refs)
+	}
+
+	for k, v := range expected {
+		refs[k].File = filepath.Base(refs[k].File)
+		if refs[k].String() != v {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s", k, v, refs[k].String())
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package a
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gaigals/localization"
+)
+
+const titleKey = "title"
+
+type user struct{ name string }
+
+func (u user) String() string { return u.name }
+
+func calls(locale *localization.Locale, key string, args []interface{}) {
+	_, _ = localization.Text(*locale, "en", titleKey)
+	_, _ = localization.Text(*locale, "en", "only_en") // want `translation key "only_en" missing for languages: lv`
+	_, _ = locale.Value("en", "nowhere")               // want `translation key "nowhere" missing for languages: en, lv`
+	_, _ = locale.Value("en", key)
+
+	_, _ = localization.Textf(*locale, "en", "hello", "John")
+	_, _ = localization.Textf(*locale, "en", "hello", user{"John"})
+	_, _ = localization.Textf(*locale, "en", "hello", errors.New("x"))
+	_, _ = localization.Textf(*locale, "en", "hello")    // want `translation "hello" \(en\) expects 1 args, got 0` `translation "hello" \(lv\) expects 1 args, got 0`
+	_, _ = localization.Textf(*locale, "en", "hello", 1) // want `translation "hello" \(en\) verb %s does not match arg 1 of type int` `translation "hello" \(lv\) verb %s does not match arg 1 of type int`
+	_, _ = localization.Textf(*locale, "en", "hello", args...)
+
+	_, _ = localization.TextPluralIntf(*locale, "en", "items", 2)
+	_, _ = localization.TextPluralIntf(*locale, "en", "bad_items", 2) // want `translation "bad_items" \(lv, plural\) verb %s does not match arg 1 of type int`
+
+	lz := locale.For("en")
+	_ = lz.T(titleKey)
+	_ = lz.TN("items", 3)
+	_ = lz.Tf("price", 1.5, "EUR")
+	_ = lz.Tf("twice", "John", "Jane")
+	_ = lz.Tf("twice", "John")       // want `translation "twice" \(lv\) expects 2 args, got 1`
+	_ = lz.Tf("price", "1.5", "EUR") // want `translation "price" \(en\) verb %.2f does not match arg 1 of type string` `translation "price" \(lv\) verb %.2\[1\]f does not match arg 1 of type string`
+
+	_ = localization.TextCtx(context.Background(), "hello")
+	_ = localization.TextCtx(context.Background(), "hello", "John", "x") // want `translation "hello" \(en\) expects 1 args, got 2` `translation "hello" \(lv\) expects 1 args, got 2`
+}
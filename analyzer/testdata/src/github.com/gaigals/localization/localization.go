@@ -0,0 +1,31 @@
+// Package localization is stub of github.com/gaigals/localization for
+// analyzer tests.
+package localization
+
+import "context"
+
+type Locale struct{}
+
+type Localizer struct{}
+
+func Text(locale Locale, langKey, textKey string) (string, error) { return "", nil }
+
+func Textf(locale Locale, langKey, textKey string, input ...interface{}) (string, error) {
+	return "", nil
+}
+
+func TextPluralIntf(locale Locale, langKey, textKey string, input ...interface{}) (string, error) {
+	return "", nil
+}
+
+func TextCtx(ctx context.Context, key string, args ...interface{}) string { return "" }
+
+func (l *Locale) Value(langKey, textKey string) (string, error) { return "", nil }
+
+func (l *Locale) For(langOrAcceptHeader ...string) Localizer { return Localizer{} }
+
+func (lz Localizer) T(key string) string { return "" }
+
+func (lz Localizer) Tf(key string, args ...interface{}) string { return "" }
+
+func (lz Localizer) TN(key string, count int, args ...interface{}) string { return "" }
@@ -0,0 +1,119 @@
+package analyzer
+
+import (
+	"go/types"
+)
+
+// Verb groups by accepted argument kind (follows fmt rules).
+const (
+	boolVerbs    = "t"
+	intVerbs     = "bcdoOqxXU"
+	floatVerbs   = "beEfFgGxX"
+	stringVerbs  = "sqxX"
+	pointerVerbs = "pbdoxX"
+)
+
+// matchesVerb checks if argument of passed type can be formatted with verb.
+// Interface types always match, as dynamic type is unknown.
+func matchesVerb(verb rune, typ types.Type) bool {
+	if verb == '*' {
+		basic, ok := typ.Underlying().(*types.Basic)
+		return ok && basic.Info()&types.IsInteger != 0
+	}
+
+	if verb == 'v' || verb == 'T' {
+		return true
+	}
+
+	if _, ok := typ.Underlying().(*types.Interface); ok {
+		return true
+	}
+
+	if isFormatter(typ) && containsVerb(stringVerbs, verb) {
+		return true
+	}
+
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		return matchesBasic(verb, t)
+	case *types.Slice:
+		if isByte(t.Elem()) && containsVerb(stringVerbs, verb) {
+			return true
+		}
+
+		return verb == 'p' || matchesVerb(verb, t.Elem())
+	case *types.Array:
+		return matchesVerb(verb, t.Elem())
+	case *types.Map:
+		return matchesVerb(verb, t.Key()) && matchesVerb(verb, t.Elem())
+	case *types.Pointer:
+		return containsVerb(pointerVerbs, verb)
+	case *types.Chan, *types.Signature:
+		return verb == 'p'
+	}
+
+	// Structs are formatted field by field.
+	return true
+}
+
+// matchesBasic checks if basic type argument can be formatted with verb.
+func matchesBasic(verb rune, t *types.Basic) bool {
+	info := t.Info()
+
+	switch {
+	case t.Kind() == types.UntypedNil:
+		return true
+	case t.Kind() == types.UnsafePointer:
+		return containsVerb(pointerVerbs, verb)
+	case info&types.IsBoolean != 0:
+		return containsVerb(boolVerbs, verb)
+	case info&types.IsInteger != 0:
+		return containsVerb(intVerbs, verb)
+	case info&(types.IsFloat|types.IsComplex) != 0:
+		return containsVerb(floatVerbs, verb)
+	case info&types.IsString != 0:
+		return containsVerb(stringVerbs, verb)
+	}
+
+	return true
+}
+
+// isFormatter checks if type (or pointer to it) implements error or
+// fmt.Stringer, so it can be formatted as string.
+func isFormatter(typ types.Type) bool {
+	for _, v := range []types.Type{typ, types.NewPointer(typ)} {
+		methods := types.NewMethodSet(v)
+
+		for _, name := range []string{"String", "Error"} {
+			selection := methods.Lookup(nil, name)
+			if selection == nil {
+				continue
+			}
+
+			signature, ok := selection.Type().(*types.Signature)
+			if ok && signature.Params().Len() == 0 && signature.Results().Len() == 1 &&
+				types.Identical(signature.Results().At(0).Type(), types.Typ[types.String]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isByte checks if type is byte.
+func isByte(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && (basic.Kind() == types.Byte || basic.Kind() == types.Uint8)
+}
+
+// containsVerb checks if verbs contain verb.
+func containsVerb(verbs string, verb rune) bool {
+	for _, v := range verbs {
+		if v == verb {
+			return true
+		}
+	}
+
+	return false
+}
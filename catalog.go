@@ -0,0 +1,120 @@
+package localization
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LoadCatalog can be used to create Locale from YAML files without knowing
+// languages in advance: languages are discovered from loaded translations
+// (in order of appearance, default language first) and initialized before
+// translations are added. Useful for tools working with translation files.
+// Returns Locale or error if something went wrong.
+// Params:
+// defaultLang - default language for non-list values (some_key: "value").
+// pattern - YAML file paths or patterns (see filepath.Glob), directories are skipped.
+func LoadCatalog(defaultLang string, pattern ...string) (*Locale, error) {
+	files, err := catalogFiles(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	yamlFiles, err := LoadYAMLFiles(defaultLang, files...)
+	if err != nil {
+		return nil, err
+	}
+
+	languages := []string{defaultLang}
+
+	for _, v := range yamlFiles {
+		for _, y := range v.Translates {
+			languages = append(languages, y.Language)
+		}
+	}
+
+	locale := &Locale{}
+
+	for _, v := range languages {
+		keyword, err := CanonicalTag(v)
+		if err != nil {
+			return nil, &LanguageError{Err: ErrInvalidLanguageTag, Language: v}
+		}
+
+		if locale.HasLanguage(keyword) {
+			continue
+		}
+
+		err = locale.AddLanguages(keyword)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = locale.AddYAMLFile(yamlFiles...)
+	if err != nil {
+		return nil, err
+	}
+
+	return locale, nil
+}
+
+// MissingLanguages can be used to find enabled languages which do not contain
+// translation key. Key must exist in language itself or its parent languages
+// ("en-GB" -> "en"), other fallback languages are ignored.
+// Returns language keywords or empty slice if key exists in all languages.
+func (l *Locale) MissingLanguages(textKey string) []string {
+	index := l.getIndex()
+	languages := make([]string, 0)
+
+	for k, v := range l.Languages {
+		if !chainContainsKey(index.chain(k, true), textKey) {
+			languages = append(languages, v.Keyword)
+		}
+	}
+
+	return languages
+}
+
+// catalogFiles expands patterns into file paths, directories are skipped.
+// Returns file paths or error if pattern is invalid or file can not be read.
+func catalogFiles(patterns []string) ([]string, error) {
+	files := make([]string, 0)
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("catalog: failed to match pattern: %w", err)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("catalog: no files match '%s'", pattern)
+		}
+
+		for _, file := range matches {
+			info, err := os.Stat(file)
+			if err != nil {
+				return nil, fmt.Errorf("catalog: failed to stat file %s: %w", file, err)
+			}
+
+			if info.IsDir() {
+				continue
+			}
+
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+// chainContainsKey checks if any of chain languages contains key.
+func chainContainsKey(chain []*Language, key string) bool {
+	for _, v := range chain {
+		if _, exist := v.Map[key]; exist {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/gaigals/localization"
+	"os"
+	"strings"
+)
+
+// coverage prints translation coverage of catalog languages and fails if
+// any language is below threshold.
+func coverage(args []string) error {
+	flags := flag.NewFlagSet("coverage", flag.ExitOnError)
+	catalog := flags.String("catalog", "", "comma separated translation YAML file paths or patterns")
+	defaultLang := flags.String("default-lang", "en", "default language for non-list YAML values")
+	source := flags.String("source", "", "source language (default language by default)")
+	format := flags.String("format", "text", "output format: text, json or markdown")
+	threshold := flags.Float64("threshold", 0, "minimal coverage percent of each language")
+
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: localization coverage [flags]")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+
+	write := map[string]func(report localization.CoverageReport) error{
+		"text":     func(report localization.CoverageReport) error { return report.WriteText(os.Stdout) },
+		"json":     func(report localization.CoverageReport) error { return report.WriteJSON(os.Stdout) },
+		"markdown": func(report localization.CoverageReport) error { return report.WriteMarkdown(os.Stdout) },
+	}[*format]
+	if write == nil {
+		return fmt.Errorf("unknown format '%s'", *format)
+	}
+
+	locale, err := localization.LoadCatalog(*defaultLang, splitList(*catalog)...)
+	if err != nil {
+		return err
+	}
+
+	if *source == "" {
+		*source = *defaultLang
+	}
+
+	report, err := locale.Coverage(*source)
+	if err != nil {
+		return err
+	}
+
+	err = write(report)
+	if err != nil {
+		return err
+	}
+
+	below := report.Below(*threshold)
+	if len(below) > 0 {
+		return fmt.Errorf("coverage below %g%%: %s", *threshold, strings.Join(below, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/gaigals/localization"
+	"os"
+)
+
+// diff prints translation changes between two catalogs.
+func diff(args []string) error {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	oldCatalog := flags.String("old", "", "comma separated old translation YAML file paths or patterns")
+	newCatalog := flags.String("new", "", "comma separated new translation YAML file paths or patterns")
+	defaultLang := flags.String("default-lang", "en", "default language for non-list YAML values")
+	languages := flags.String("lang", "", "comma separated languages to report changes for (all by default)")
+	format := flags.String("format", "text", "output format: text or json")
+
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: localization diff -old=<patterns> -new=<patterns> [flags]")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format '%s'", *format)
+	}
+
+	changes, err := localization.DiffCatalogs(*defaultLang, splitList(*oldCatalog), splitList(*newCatalog))
+	if err != nil {
+		return err
+	}
+
+	if *languages != "" {
+		changes = changes.Filter(splitList(*languages)...)
+	}
+
+	if *format == "json" {
+		return changes.WriteJSON(os.Stdout)
+	}
+
+	return changes.WriteText(os.Stdout)
+}
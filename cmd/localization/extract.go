@@ -0,0 +1,177 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/gaigals/localization"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// extract scans Go packages and templates for translation keys and adds keys
+// missing in catalog languages to YAML files with empty values.
+func extract(args []string) error {
+	flags := flag.NewFlagSet("extract", flag.ExitOnError)
+	catalog := flags.String("catalog", "", "comma separated translation YAML file paths or patterns")
+	defaultLang := flags.String("default-lang", "en", "default language for non-list YAML values")
+	out := flags.String("out", "", "YAML file for new keys (the first catalog file by default)")
+	langs := flags.String("lang", "", "comma separated languages to add keys for (catalog languages by default)")
+	templates := flags.String("templates", "", "comma separated template file patterns")
+	dryRun := flags.Bool("dry-run", false, "print keys without changing files")
+
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: localization extract [flags] [packages]")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+
+	patterns := splitList(*catalog)
+	if *out != "" {
+		if _, err := os.Stat(*out); err == nil {
+			patterns = append(patterns, *out)
+		}
+	}
+
+	locale, err := loadCatalog(*defaultLang, patterns)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		*out = firstCatalogFile(patterns)
+	}
+
+	if *out == "" {
+		return errors.New("output file is not set (-out flag)")
+	}
+
+	refs, err := scanKeys(flags.Args(), splitList(*templates))
+	if err != nil {
+		return err
+	}
+
+	languages := splitList(*langs)
+	if len(languages) == 0 {
+		languages = locale.EnabledLanguages()
+	}
+
+	if len(languages) == 0 {
+		languages = []string{*defaultLang}
+	}
+
+	entries := extractEntries(locale, refs, languages, *out)
+
+	files := make([]string, 0, len(entries))
+	for k := range entries {
+		files = append(files, k)
+	}
+
+	sort.Strings(files)
+
+	for _, file := range files {
+		if *dryRun {
+			for _, v := range entries[file] {
+				fmt.Printf("%s: %s %v\n", file, v.Key, v.Languages)
+			}
+
+			continue
+		}
+
+		count, err := localization.AppendYAMLKeys(file, *defaultLang, entries[file]...)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s: added %d entries\n", file, count)
+	}
+
+	return nil
+}
+
+// extractEntries groups keys missing in languages by YAML file: keys already
+// defined in catalog go to the file they are defined in, new keys go to out.
+func extractEntries(locale *localization.Locale, refs []localization.KeyRef, languages []string, out string) map[string][]localization.YAMLKeyEntry {
+	entries := make(map[string][]localization.YAMLKeyEntry)
+	seen := make(map[string]bool)
+
+	for _, ref := range refs {
+		if seen[ref.Key] {
+			continue
+		}
+
+		seen[ref.Key] = true
+
+		missing := missingLanguages(locale, ref.Key, languages)
+		if len(missing) == 0 {
+			continue
+		}
+
+		file := keyFile(locale, ref.Key)
+		if file == "" {
+			file = out
+		}
+
+		entries[file] = append(entries[file], localization.YAMLKeyEntry{
+			Key:       ref.Key,
+			Languages: missing,
+			Comment:   fmt.Sprintf("%s:%d", relativePath(ref.File), ref.Line),
+		})
+	}
+
+	return entries
+}
+
+// missingLanguages returns languages, which do not contain key.
+func missingLanguages(locale *localization.Locale, key string, languages []string) []string {
+	inCatalog := locale.MissingLanguages(key)
+	missing := make([]string, 0)
+
+	for _, v := range languages {
+		keyword, err := localization.CanonicalTag(v)
+		if err != nil {
+			keyword = v
+		}
+
+		if !locale.HasLanguage(keyword) || containsString(inCatalog, keyword) {
+			missing = append(missing, keyword)
+		}
+	}
+
+	return missing
+}
+
+// keyFile returns YAML file key is defined in, empty string if key is not
+// defined in catalog.
+func keyFile(locale *localization.Locale, key string) string {
+	for _, v := range locale.Languages {
+		if _, exist := v.Map[key]; !exist {
+			continue
+		}
+
+		result, err := locale.Lookup(v.Keyword, key)
+		if err == nil && result.Source.File != "" {
+			return result.Source.File
+		}
+	}
+
+	return ""
+}
+
+// firstCatalogFile returns the first file matching catalog patterns.
+func firstCatalogFile(patterns []string) string {
+	for _, pattern := range patterns {
+		files, _ := filepath.Glob(pattern)
+
+		for _, file := range files {
+			info, err := os.Stat(file)
+			if err == nil && !info.IsDir() {
+				return file
+			}
+		}
+	}
+
+	return ""
+}
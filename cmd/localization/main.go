@@ -0,0 +1,85 @@
+// Command localization provides tools for working with translation catalogs
+// (YAML translate files) of github.com/gaigals/localization:
+//
+//	localization extract [flags] [packages] - add keys used in Go code and templates to catalog.
+//	localization unused [flags] [packages] - report (and prune) keys not used in Go code and templates.
+//	localization coverage [flags] - report translation coverage of catalog languages.
+//	localization diff -old=<patterns> -new=<patterns> [flags] - report translation changes between catalogs.
+//
+// Run "localization <command> -h" for command flags.
+package main
+
+import (
+	"fmt"
+	"github.com/gaigals/localization"
+	"os"
+	"strings"
+)
+
+// command is tool subcommand.
+type command struct {
+	run   func(args []string) error
+	usage string
+}
+
+// commands are available subcommands by name.
+var commands = map[string]command{
+	"extract":  {extract, "add keys used in Go code and templates to catalog"},
+	"unused":   {unused, "report (and prune) keys not used in Go code and templates"},
+	"coverage": {coverage, "report translation coverage of catalog languages"},
+	"diff":     {diff, "report translation changes between two catalogs"},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, exist := commands[os.Args[1]]
+	if !exist {
+		fmt.Fprintf(os.Stderr, "localization: unknown command '%s'\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	err := cmd.run(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "localization %s: %s\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+// usage prints available commands.
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: localization <command> [flags] [args]")
+	fmt.Fprintln(os.Stderr, "commands:")
+
+	for _, name := range []string{"extract", "unused", "coverage", "diff"} {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].usage)
+	}
+}
+
+// splitList splits comma separated flag value, empty items are skipped.
+func splitList(value string) []string {
+	items := make([]string, 0)
+
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			items = append(items, v)
+		}
+	}
+
+	return items
+}
+
+// loadCatalog loads catalog from YAML file patterns, empty Locale is
+// returned if there are no patterns.
+func loadCatalog(defaultLang string, patterns []string) (*localization.Locale, error) {
+	if len(patterns) == 0 {
+		return &localization.Locale{}, nil
+	}
+
+	return localization.LoadCatalog(defaultLang, patterns...)
+}
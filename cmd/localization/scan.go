@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gaigals/localization"
+	"github.com/gaigals/localization/analyzer"
+	"os"
+	"path/filepath"
+)
+
+// scanKeys finds translation keys in Go packages ("./..." if no patterns) and
+// template files, directories matching template patterns are skipped.
+func scanKeys(packages, templates []string) ([]localization.KeyRef, error) {
+	if len(packages) == 0 {
+		packages = []string{"./..."}
+	}
+
+	refs, err := analyzer.Keys("", packages...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pattern := range templates {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to match templates: %w", err)
+		}
+
+		for _, file := range files {
+			// Patterns like "templates/*" match directories too.
+			info, err := os.Stat(file)
+			if err != nil {
+				return nil, err
+			}
+
+			if info.IsDir() {
+				continue
+			}
+
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+
+			found, err := localization.TemplateKeys(file, string(content), "", "", localization.DefaultTemplateKeyFuncs())
+			if err != nil {
+				return nil, err
+			}
+
+			refs = append(refs, found...)
+		}
+	}
+
+	return refs, nil
+}
+
+// relativePath returns path relative to working directory if possible.
+func relativePath(path string) string {
+	dir, err := os.Getwd()
+	if err != nil || !filepath.IsAbs(path) {
+		return path
+	}
+
+	relative, err := filepath.Rel(dir, path)
+	if err != nil {
+		return path
+	}
+
+	return relative
+}
+
+// containsString checks if values contain value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
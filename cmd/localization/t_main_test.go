@@ -0,0 +1,210 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mainEnv makes test binary run main instead of tests (see runCommand).
+const mainEnv = "LOCALIZATION_TEST_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(mainEnv) == "1" {
+		os.Args[0] = "localization"
+		main()
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+// runCommand runs command in testdata/src/app package directory (GOPATH
+// mode with stub of localization package).
+// Returns output, error output and exit code.
+func runCommand(t *testing.T, args ...string) (string, string, int) {
+	testdata, _ := filepath.Abs("testdata")
+
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Dir = filepath.Join(testdata, "src", "app")
+	cmd.Env = append(os.Environ(), mainEnv+"=1", "GOPATH="+testdata, "GO111MODULE=off", "GOFLAGS=")
+
+	stdout, stderr := strings.Builder{}, strings.Builder{}
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	err := cmd.Run()
+
+	exitErr := &exec.ExitError{}
+	if errors.As(err, &exitErr) {
+		return stdout.String(), stderr.String(), exitErr.ExitCode()
+	}
+
+	if err != nil {
+		t.Fatalf("failed to run command: %s", err)
+	}
+
+	return stdout.String(), stderr.String(), 0
+}
+
+// writeCatalog writes YAML catalog into temp directory.
+// Returns file path.
+func writeCatalog(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "catalog.yml")
+
+	err := os.WriteFile(path, []byte(content), 0o644)
+	if err != nil {
+		t.Fatalf("failed to write catalog: %s", err)
+	}
+
+	return path
+}
+
+func TestExtract(t *testing.T) {
+	templates, _ := filepath.Abs(filepath.Join("testdata", "templates", "*"))
+
+	testCases := []struct {
+		content  string
+		args     []string
+		expected string
+		output   string
+	}{
+		{"# Header\ntitle:\n  - en: \"Title\"\n  - lv: \"Virsraksts\"\nhello: \"Hello\"\n", nil,
+			"# Header\ntitle:\n  - en: \"Title\"\n  - lv: \"Virsraksts\"\nhello:\n  - en: \"Hello\"\n  - lv: \"\"\n\n" +
+				"# TODO app.go:10\nnew_key:\n  - en: \"\"\n  - lv: \"\"\n", "added 3 entries"},
+		{"title:\n  - en: \"Title\"\n  - lv: \"Virsraksts\"\nhello: \"Hello\"\n", []string{"-templates=" + templates},
+			"title:\n  - en: \"Title\"\n  - lv: \"Virsraksts\"\nhello:\n  - en: \"Hello\"\n  - lv: \"\"\n\n" +
+				"# TODO app.go:10\nnew_key:\n  - en: \"\"\n  - lv: \"\"\n\n" +
+				"# TODO " + filepath.Join("..", "..", "templates", "page.html") + ":2\ntemplate_key:\n  - en: \"\"\n  - lv: \"\"\n",
+			"added 5 entries"},
+		{"title:\n  - en: \"Title\"\n  - lv: \"Virsraksts\"\nhello: \"Hello\"\n", []string{"-dry-run"},
+			"title:\n  - en: \"Title\"\n  - lv: \"Virsraksts\"\nhello: \"Hello\"\n", "new_key [en lv]"},
+	}
+
+	for k, v := range testCases {
+		path := writeCatalog(t, v.content)
+
+		args := append([]string{"extract", "-catalog=" + path}, v.args...)
+
+		stdout, stderr, code := runCommand(t, append(args, ".")...)
+		if code != 0 {
+			t.Fatalf("unexpected exit code, index=%d, expected=0, actual=%d, stderr=%s", k, code, stderr)
+		}
+
+		if !strings.Contains(stdout, v.output) {
+			t.Fatalf("unexpected output, index=%d, expected=%s, actual=%s", k, v.output, stdout)
+		}
+
+		received, _ := os.ReadFile(path)
+		if string(received) != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s", k, v.expected, received)
+		}
+	}
+}
+
+func TestUnused(t *testing.T) {
+	templates, _ := filepath.Abs(filepath.Join("testdata", "templates", "*"))
+	content := "# Header\ntitle: \"Title\"\n\n# Old page\nold_key: \"Old\"\nhello: \"Hello\"\n\n" +
+		"# Template\ntemplate_key: \"Template\"\n"
+
+	testCases := []struct {
+		args     []string
+		expected string
+		output   string
+	}{
+		{nil, content, "2 unused keys"},
+		{[]string{"-prune"}, "# Header\ntitle: \"Title\"\n\nhello: \"Hello\"\n", "removed 2 keys"},
+		{[]string{"-prune", "-templates=" + templates}, "# Header\ntitle: \"Title\"\n\nhello: \"Hello\"\n\n" +
+			"# Template\ntemplate_key: \"Template\"\n", "removed 1 keys"},
+		{[]string{"-prune", "-keep=old_*,template_*"}, content, "0 unused keys"},
+	}
+
+	for k, v := range testCases {
+		path := writeCatalog(t, content)
+
+		args := append([]string{"unused", "-catalog=" + path}, v.args...)
+
+		stdout, stderr, code := runCommand(t, append(args, ".")...)
+		if code != 0 {
+			t.Fatalf("unexpected exit code, index=%d, expected=0, actual=%d, stderr=%s", k, code, stderr)
+		}
+
+		if !strings.Contains(stdout, v.output) {
+			t.Fatalf("unexpected output, index=%d, expected=%s, actual=%s", k, v.output, stdout)
+		}
+
+		received, _ := os.ReadFile(path)
+		if string(received) != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s", k, v.expected, received)
+		}
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	path := writeCatalog(t, "key0:\n  - en: \"a\"\n  - lv: \"a\"\nkey1:\n  - en: \"b\"\nkey2: \"c\"\nkey3: \"d\"\n")
+
+	testCases := []struct {
+		args   []string
+		code   int
+		output string
+	}{
+		{nil, 0, "lv"},
+		{[]string{"-threshold=25"}, 0, "lv"},
+		{[]string{"-threshold=50"}, 1, "coverage below 50%: lv"},
+		{[]string{"-format=json", "-threshold=100"}, 1, "coverage below 100%: lv"},
+		{[]string{"-format=xml"}, 1, "unknown format 'xml'"},
+	}
+
+	for k, v := range testCases {
+		stdout, stderr, code := runCommand(t, append([]string{"coverage", "-catalog=" + path}, v.args...)...)
+		if code != v.code {
+			t.Fatalf("unexpected exit code, index=%d, expected=%d, actual=%d, stderr=%s", k, v.code, code, stderr)
+		}
+
+		if !strings.Contains(stdout+stderr, v.output) {
+			t.Fatalf("unexpected output, index=%d, expected=%s, actual=%s", k, v.output, stdout+stderr)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	oldPath := writeCatalog(t, "key0:\n  - en: \"Hello\"\n  - lv: \"Sveiki\"\n")
+	newPath := writeCatalog(t, "key0:\n  - en: \"Hello\"\n  - lv: \"Labdien\"\nkey1: \"New\"\n")
+
+	testCases := []struct {
+		args   []string
+		code   int
+		output string
+	}{
+		{nil, 0, "~ key0 (lv): \"Sveiki\" -> \"Labdien\"\n+ key1 (en): \"New\"\n1 keys added"},
+		{[]string{"-lang=LV"}, 0, "~ key0 (lv): \"Sveiki\" -> \"Labdien\"\n1 keys added, 0 keys removed, 1 value"},
+		{[]string{"-format=json"}, 0, `"kind": "added"`},
+		{[]string{"-format=xml"}, 1, "unknown format 'xml'"},
+	}
+
+	for k, v := range testCases {
+		args := append([]string{"diff", "-old=" + oldPath, "-new=" + newPath}, v.args...)
+
+		stdout, stderr, code := runCommand(t, args...)
+		if code != v.code {
+			t.Fatalf("unexpected exit code, index=%d, expected=%d, actual=%d, stderr=%s", k, v.code, code, stderr)
+		}
+
+		if !strings.Contains(stdout+stderr, v.output) {
+			t.Fatalf("unexpected output, index=%d, expected=%s, actual=%s", k, v.output, stdout+stderr)
+		}
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	testCases := [][]string{nil, {"none"}}
+
+	for k, v := range testCases {
+		_, stderr, code := runCommand(t, v...)
+		if code != 2 || !strings.Contains(stderr, "usage: localization <command>") {
+			t.Fatalf("unexpected result, index=%d, expected=2, actual=%d, stderr=%s", k, code, stderr)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package app
+
+import "github.com/gaigals/localization"
+
+func page(locale *localization.Locale) {
+	_, _ = localization.Text(*locale, "en", "title")
+
+	lz := locale.For("en")
+	_ = lz.T("hello")
+	_ = lz.T("new_key")
+}
@@ -0,0 +1,13 @@
+// Package localization is stub of github.com/gaigals/localization for
+// command tests.
+package localization
+
+type Locale struct{}
+
+type Localizer struct{}
+
+func Text(locale Locale, langKey, textKey string) (string, error) { return "", nil }
+
+func (l *Locale) For(langOrAcceptHeader ...string) Localizer { return Localizer{} }
+
+func (lz Localizer) T(key string) string { return "" }
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/gaigals/localization"
+	"sort"
+	"strings"
+)
+
+// unused reports catalog keys, which are not referenced in Go packages and
+// templates, and optionally removes them from YAML files.
+func unused(args []string) error {
+	flags := flag.NewFlagSet("unused", flag.ExitOnError)
+	catalog := flags.String("catalog", "", "comma separated translation YAML file paths or patterns")
+	defaultLang := flags.String("default-lang", "en", "default language for non-list YAML values")
+	templates := flags.String("templates", "", "comma separated template file patterns")
+	keep := flags.String("keep", "", "comma separated patterns of keys used at runtime (\"error.*\")")
+	prune := flags.Bool("prune", false, "remove unused keys from YAML files")
+
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: localization unused [flags] [packages]")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+
+	locale, err := localization.LoadCatalog(*defaultLang, splitList(*catalog)...)
+	if err != nil {
+		return err
+	}
+
+	refs, err := scanKeys(flags.Args(), splitList(*templates))
+	if err != nil {
+		return err
+	}
+
+	used := make([]string, len(refs))
+	for k, v := range refs {
+		used[k] = v.Key
+	}
+
+	keys, err := locale.UnusedKeys(used, splitList(*keep)...)
+	if err != nil {
+		return err
+	}
+
+	files := make(map[string][]string)
+
+	for _, v := range keys {
+		locations := make([]string, len(v.Sources))
+
+		for k, source := range v.Sources {
+			locations[k] = fmt.Sprintf("%s:%d", source.File, source.Line)
+			files[source.File] = append(files[source.File], v.Key)
+		}
+
+		fmt.Printf("%s\t%s\t%s\n", v.Key, strings.Join(v.Languages, ", "), strings.Join(locations, ", "))
+	}
+
+	fmt.Printf("%d unused keys\n", len(keys))
+
+	if !*prune {
+		return nil
+	}
+
+	paths := make([]string, 0, len(files))
+	for k := range files {
+		paths = append(paths, k)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		count, err := localization.RemoveYAMLKeys(path, files[path]...)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s: removed %d keys\n", path, count)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+// Command localizationvet checks calls of github.com/gaigals/localization
+// translation functions against translation catalog (see package analyzer).
+// Can be used standalone or with go vet:
+//
+//	localizationvet -catalog="locales/*.yml" ./...
+//	go vet -vettool=$(which localizationvet) -catalog="$PWD/locales/*.yml" ./...
+package main
+
+import (
+	"github.com/gaigals/localization/analyzer"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
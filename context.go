@@ -0,0 +1,80 @@
+package localization
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// localizerContextKey is context key for Localizer (see WithLocalizer).
+type localizerContextKey struct{}
+
+// defaultLocalizer holds Localizer used by FromContext when context does not
+// contain Localizer (see SetDefaultLocalizer).
+var defaultLocalizer atomic.Pointer[Localizer]
+
+// WithLocalizer can be used to store Localizer in context, so code deeper in
+// the call chain can localize texts without passing language around.
+// Returns new context with Localizer.
+func WithLocalizer(ctx context.Context, localizer Localizer) context.Context {
+	return context.WithValue(ctx, localizerContextKey{}, localizer)
+}
+
+// FromContext returns Localizer stored in context with WithLocalizer or
+// default Localizer (see SetDefaultLocalizer) if context does not contain it.
+// Returns zero value Localizer (empty texts, Locale.MissingBehavior is not
+// applied) if neither is set.
+func FromContext(ctx context.Context) Localizer {
+	if ctx != nil {
+		localizer, ok := ctx.Value(localizerContextKey{}).(Localizer)
+		if ok {
+			return localizer
+		}
+	}
+
+	localizer := defaultLocalizer.Load()
+	if localizer == nil {
+		return Localizer{}
+	}
+
+	return *localizer
+}
+
+// SetDefaultLocalizer sets Localizer used by FromContext when context does
+// not contain Localizer, for example:
+//
+//	localization.SetDefaultLocalizer(locale.For("en"))
+//
+// Safe for concurrent use.
+func SetDefaultLocalizer(localizer Localizer) {
+	defaultLocalizer.Store(&localizer)
+}
+
+// TextCtx returns non-plural translation using Localizer from context (see
+// FromContext). If args are passed, translation gets formatted like with Textf.
+// Returns missing key text (see Locale.MissingBehavior, empty string for
+// MissingAsError) if key does not exist.
+func TextCtx(ctx context.Context, key string, args ...interface{}) string {
+	if len(args) == 0 {
+		return FromContext(ctx).T(key)
+	}
+
+	return FromContext(ctx).Tf(key, args...)
+}
+
+// TextPluralCtx returns plural translation if count is greater than 1 or
+// non-plural translation otherwise, using Localizer from context (see
+// FromContext and Localizer.TN).
+// Returns missing key text (see Locale.MissingBehavior, empty string for
+// MissingAsError) if key does not exist.
+func TextPluralCtx(ctx context.Context, key string, count int, args ...interface{}) string {
+	return FromContext(ctx).TN(key, count, args...)
+}
+
+// TextNamedCtx returns non-plural translation with named placeholders replaced
+// by passed args, using Localizer from context (see FromContext and
+// Localizer.TNamed).
+// Returns missing key text (see Locale.MissingBehavior, empty string for
+// MissingAsError) if key does not exist.
+func TextNamedCtx(ctx context.Context, key string, args map[string]interface{}) string {
+	return FromContext(ctx).TNamed(key, args)
+}
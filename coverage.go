@@ -0,0 +1,179 @@
+package localization
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// LanguageCoverage holds translation statistics of language compared to
+// source language (see Locale.Coverage).
+type LanguageCoverage struct {
+	Language       string   `json:"language"`       // Language keyword.
+	Keys           int      `json:"keys"`           // Count of keys defined in language itself.
+	Missing        []string `json:"missing"`        // Source keys not defined in language and its parent languages.
+	MissingPlurals []string `json:"missingPlurals"` // Keys with source plural value, but without plural value.
+	Empty          []string `json:"empty"`          // Keys defined with empty value.
+	Identical      []string `json:"identical"`      // Keys with value identical to source value.
+	Coverage       float64  `json:"coverage"`       // Percent of source keys with non-empty value.
+}
+
+// CoverageReport holds translation statistics of all Locale languages.
+type CoverageReport struct {
+	Source    string             `json:"source"`    // Source language keyword.
+	Keys      int                `json:"keys"`      // Count of source language keys.
+	Languages []LanguageCoverage `json:"languages"` // Statistics in Locale.Languages order.
+}
+
+// Coverage can be used to compute translation statistics for each language
+// compared to source language: count of keys, missing keys, missing plural
+// values, empty values and values identical to source. Keys and values of
+// parent languages ("en" for "en-GB") count as translated, but only values
+// defined in language itself are checked for empty and identical values.
+// Returns CoverageReport or error if source language does not exist.
+// Params:
+// sourceLang - source language keyword ("en").
+func (l *Locale) Coverage(sourceLang string) (CoverageReport, error) {
+	source, err := l.GetLanguage(sourceLang)
+	if err != nil {
+		return CoverageReport{}, err
+	}
+
+	keys := make([]string, 0, len(source.Map))
+	for k := range source.Map {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	report := CoverageReport{Source: source.Keyword, Keys: len(keys), Languages: make([]LanguageCoverage, 0)}
+	index := l.getIndex()
+
+	for k := range l.Languages {
+		language := &l.Languages[k]
+		chain := index.chain(k, true)
+
+		coverage := LanguageCoverage{
+			Language:       language.Keyword,
+			Keys:           len(language.Map),
+			Missing:        make([]string, 0),
+			MissingPlurals: make([]string, 0),
+			Empty:          make([]string, 0),
+			Identical:      make([]string, 0),
+			Coverage:       100,
+		}
+
+		translated := 0
+
+		for _, key := range keys {
+			expected := source.Map[key]
+
+			translation, own, exist := chainTranslation(chain, key)
+			if !exist {
+				coverage.Missing = append(coverage.Missing, key)
+				continue
+			}
+
+			if translation[0] != "" {
+				translated++
+			}
+
+			if expected[1] != "" && translation[1] == "" {
+				coverage.MissingPlurals = append(coverage.MissingPlurals, key)
+			}
+
+			if !own {
+				continue
+			}
+
+			if translation[0] == "" {
+				coverage.Empty = append(coverage.Empty, key)
+			} else if language != source && translation == expected {
+				coverage.Identical = append(coverage.Identical, key)
+			}
+		}
+
+		if len(keys) > 0 {
+			coverage.Coverage = float64(translated) * 100 / float64(len(keys))
+		}
+
+		report.Languages = append(report.Languages, coverage)
+	}
+
+	return report, nil
+}
+
+// Below returns keywords of languages with coverage below threshold (percent).
+func (r CoverageReport) Below(threshold float64) []string {
+	languages := make([]string, 0)
+
+	for _, v := range r.Languages {
+		if v.Coverage < threshold {
+			languages = append(languages, v.Language)
+		}
+	}
+
+	return languages
+}
+
+// WriteText writes report as text table.
+// Returns error if write fails.
+func (r CoverageReport) WriteText(w io.Writer) error {
+	writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(writer, "Source: %s (%d keys)\n", r.Source, r.Keys)
+	fmt.Fprintln(writer, "LANGUAGE\tKEYS\tMISSING\tMISSING PLURALS\tEMPTY\tIDENTICAL\tCOVERAGE")
+
+	for _, v := range r.Languages {
+		fmt.Fprintf(writer, "%s\t%d\t%d\t%d\t%d\t%d\t%.1f%%\n", v.Language, v.Keys, len(v.Missing),
+			len(v.MissingPlurals), len(v.Empty), len(v.Identical), v.Coverage)
+	}
+
+	return writer.Flush()
+}
+
+// WriteJSON writes report as indented JSON.
+// Returns error if write fails.
+func (r CoverageReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+// WriteMarkdown writes report as Markdown table.
+// Returns error if write fails.
+func (r CoverageReport) WriteMarkdown(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "Source: `%s` (%d keys)\n\n"+
+		"| Language | Keys | Missing | Missing plurals | Empty | Identical | Coverage |\n"+
+		"|---|---:|---:|---:|---:|---:|---:|\n", r.Source, r.Keys)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range r.Languages {
+		_, err = fmt.Fprintf(w, "| `%s` | %d | %d | %d | %d | %d | %.1f%% |\n", v.Language, v.Keys,
+			len(v.Missing), len(v.MissingPlurals), len(v.Empty), len(v.Identical), v.Coverage)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// chainTranslation returns translation of key from the first chain language
+// containing it, whether it came from the first (own) language and whether it
+// exists.
+func chainTranslation(chain []*Language, key string) ([2]string, bool, bool) {
+	for k, v := range chain {
+		translation, exist := v.Map[key]
+		if exist {
+			return translation, k == 0, true
+		}
+	}
+
+	return [2]string{}, false, false
+}
@@ -0,0 +1,247 @@
+package localization
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ChangeKind describes kind of translation value change.
+type ChangeKind string
+
+// Translation value change kinds.
+const (
+	ChangeAdded   ChangeKind = "added"   // Value exists only in new catalog.
+	ChangeRemoved ChangeKind = "removed" // Value exists only in old catalog.
+	ChangeChanged ChangeKind = "changed" // Value exists in both catalogs, but differs.
+)
+
+// CatalogChange holds translation value change of single key and language
+// (see DiffLocales).
+type CatalogChange struct {
+	Key           string     `json:"key"`           // Translation keyword/key.
+	Language      string     `json:"language"`      // Language keyword.
+	Kind          ChangeKind `json:"kind"`          // Change kind.
+	Old           [2]string  `json:"old"`           // Old non-plural and plural value, empty for added values.
+	New           [2]string  `json:"new"`           // New non-plural and plural value, empty for removed values.
+	ValueChanged  bool       `json:"valueChanged"`  // Non-plural value differs.
+	PluralChanged bool       `json:"pluralChanged"` // Plural form differs (added, removed or changed).
+	Source        Source     `json:"source"`        // Value source file and line (old catalog for removed values).
+}
+
+// String returns change description, for example:
+// ~ key (lv): "Sveiki" -> "Labdien"; plural "" -> "%d lietas".
+func (c CatalogChange) String() string {
+	parts := make([]string, 0, 2)
+
+	switch c.Kind {
+	case ChangeAdded, ChangeRemoved:
+		value := c.New
+		if c.Kind == ChangeRemoved {
+			value = c.Old
+		}
+
+		parts = append(parts, fmt.Sprintf("%q", value[0]))
+		if value[1] != "" {
+			parts = append(parts, fmt.Sprintf("plural %q", value[1]))
+		}
+	default:
+		if c.ValueChanged {
+			parts = append(parts, fmt.Sprintf("%q -> %q", c.Old[0], c.New[0]))
+		}
+
+		if c.PluralChanged {
+			parts = append(parts, fmt.Sprintf("plural %q -> %q", c.Old[1], c.New[1]))
+		}
+	}
+
+	sign := map[ChangeKind]string{ChangeAdded: "+", ChangeRemoved: "-", ChangeChanged: "~"}[c.Kind]
+
+	return fmt.Sprintf("%s %s (%s): %s", sign, c.Key, c.Language, strings.Join(parts, "; "))
+}
+
+// CatalogDiff holds differences between two catalogs (see DiffLocales).
+type CatalogDiff struct {
+	AddedKeys   []string        `json:"addedKeys"`   // Keys not defined in any old catalog language.
+	RemovedKeys []string        `json:"removedKeys"` // Keys not defined in any new catalog language.
+	Changes     []CatalogChange `json:"changes"`     // Value changes sorted by key, then by language order.
+}
+
+// DiffLocales can be used to compare two Locale translations, for example,
+// catalogs of two releases. Only values defined in language itself are
+// compared (parent and fallback languages are not used), languages are
+// matched by keyword.
+// Returns added and removed keys and value changes per language.
+// Params:
+// from - old Locale.
+// to - new Locale.
+func DiffLocales(from, to *Locale) CatalogDiff {
+	languages := make([]string, 0, len(from.Languages)+len(to.Languages))
+	oldMaps := make(map[string]TextMap, len(from.Languages))
+	newMaps := make(map[string]TextMap, len(to.Languages))
+
+	for _, v := range from.Languages {
+		oldMaps[v.Keyword] = v.Map
+		languages = append(languages, v.Keyword)
+	}
+
+	for _, v := range to.Languages {
+		newMaps[v.Keyword] = v.Map
+		if !containsString(languages, v.Keyword) {
+			languages = append(languages, v.Keyword)
+		}
+	}
+
+	oldKeys, newKeys := localeKeys(from), localeKeys(to)
+	keys := make([]string, 0, len(oldKeys)+len(newKeys))
+
+	diff := CatalogDiff{AddedKeys: make([]string, 0), RemovedKeys: make([]string, 0), Changes: make([]CatalogChange, 0)}
+
+	for k := range oldKeys {
+		keys = append(keys, k)
+		if !newKeys[k] {
+			diff.RemovedKeys = append(diff.RemovedKeys, k)
+		}
+	}
+
+	for k := range newKeys {
+		if !oldKeys[k] {
+			keys = append(keys, k)
+			diff.AddedKeys = append(diff.AddedKeys, k)
+		}
+	}
+
+	sort.Strings(keys)
+	sort.Strings(diff.AddedKeys)
+	sort.Strings(diff.RemovedKeys)
+
+	for _, key := range keys {
+		for _, language := range languages {
+			oldValue, inOld := oldMaps[language][key]
+			newValue, inNew := newMaps[language][key]
+
+			change := CatalogChange{
+				Key:           key,
+				Language:      language,
+				Old:           oldValue,
+				New:           newValue,
+				ValueChanged:  oldValue[0] != newValue[0],
+				PluralChanged: oldValue[1] != newValue[1],
+				Source:        to.sources[sourceKey{language, key}],
+			}
+
+			switch {
+			case inOld && inNew:
+				if oldValue == newValue {
+					continue
+				}
+
+				change.Kind = ChangeChanged
+			case inNew:
+				change.Kind = ChangeAdded
+			case inOld:
+				change.Kind = ChangeRemoved
+				change.Source = from.sources[sourceKey{language, key}]
+			default:
+				continue
+			}
+
+			diff.Changes = append(diff.Changes, change)
+		}
+	}
+
+	return diff
+}
+
+// DiffCatalogs can be used to compare two sets of YAML translate files (see
+// LoadCatalog and DiffLocales).
+// Returns catalog differences or error if files could not be loaded.
+// Params:
+// defaultLang - default language for non-list values (some_key: "value").
+// oldPatterns - old catalog file paths or patterns.
+// newPatterns - new catalog file paths or patterns.
+func DiffCatalogs(defaultLang string, oldPatterns, newPatterns []string) (CatalogDiff, error) {
+	from, err := LoadCatalog(defaultLang, oldPatterns...)
+	if err != nil {
+		return CatalogDiff{}, fmt.Errorf("old catalog: %w", err)
+	}
+
+	to, err := LoadCatalog(defaultLang, newPatterns...)
+	if err != nil {
+		return CatalogDiff{}, fmt.Errorf("new catalog: %w", err)
+	}
+
+	return DiffLocales(from, to), nil
+}
+
+// Empty checks if catalogs have no differences.
+func (d CatalogDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// Filter returns diff with changes of passed languages only, keys are kept.
+// Language keywords are compared in canonical form ("en_gb" matches "en-GB").
+func (d CatalogDiff) Filter(languages ...string) CatalogDiff {
+	filtered := CatalogDiff{AddedKeys: d.AddedKeys, RemovedKeys: d.RemovedKeys, Changes: make([]CatalogChange, 0)}
+
+	keywords := make([]string, len(languages))
+	for k, v := range languages {
+		keywords[k] = normalizeKeyword(v)
+	}
+
+	for _, v := range d.Changes {
+		if containsString(keywords, normalizeKeyword(v.Language)) {
+			filtered.Changes = append(filtered.Changes, v)
+		}
+	}
+
+	return filtered
+}
+
+// WriteText writes changes (one per line, see CatalogChange.String) and
+// summary line.
+// Returns error if write fails.
+func (d CatalogDiff) WriteText(w io.Writer) error {
+	for _, v := range d.Changes {
+		_, err := fmt.Fprintln(w, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	plurals := 0
+	for _, v := range d.Changes {
+		if v.PluralChanged {
+			plurals++
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "%d keys added, %d keys removed, %d value changes (%d plural)\n",
+		len(d.AddedKeys), len(d.RemovedKeys), len(d.Changes), plurals)
+
+	return err
+}
+
+// WriteJSON writes diff as indented JSON.
+// Returns error if write fails.
+func (d CatalogDiff) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(d)
+}
+
+// localeKeys returns set of keys defined in any Locale language.
+func localeKeys(l *Locale) map[string]bool {
+	keys := make(map[string]bool)
+
+	for _, language := range l.Languages {
+		for k := range language.Map {
+			keys[k] = true
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,166 @@
+package localization
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrLanguageNotFound gets returned (wrapped in LanguageError) when language
+// with requested keyword is not initialized.
+var ErrLanguageNotFound = errors.New("language does not exist")
+
+// ErrLanguageExists gets returned (wrapped in LanguageError) when language
+// with passed keyword is already initialized or redefined.
+var ErrLanguageExists = errors.New("language already exists")
+
+// ErrInvalidLanguageTag gets returned (wrapped in LanguageError) when language
+// keyword is not valid BCP 47 language tag.
+var ErrInvalidLanguageTag = errors.New("invalid language tag")
+
+// ErrInvalidFallback gets returned (wrapped in LanguageError) when declared
+// fallback chain is not valid, for example, language falls back to itself
+// (see Locale.SetFallback).
+var ErrInvalidFallback = errors.New("invalid fallback")
+
+// ErrKeyNotFound gets returned (as KeyError) when translation key does not
+// exist in requested language and its fallback languages.
+var ErrKeyNotFound = errors.New("key does not exist")
+
+// ErrYAMLRead gets returned (wrapped in YAMLError) when YAML file can not be read.
+var ErrYAMLRead = errors.New("failed to read file")
+
+// ErrYAMLSyntax gets returned (wrapped in YAMLError) when YAML file can not
+// be unmarshalled.
+var ErrYAMLSyntax = errors.New("failed to unmarshal")
+
+// ErrYAMLUnsupportedValue gets returned (wrapped in YAMLError) when YAML
+// translation value has unsupported type.
+var ErrYAMLUnsupportedValue = errors.New("unsupported value")
+
+// ErrYAMLTooManyPlurals gets returned (wrapped in YAMLError) when YAML
+// translation contains more than 2 plural entries.
+var ErrYAMLTooManyPlurals = errors.New("contains more than 2 plural entries")
+
+// ErrRichTextSyntax gets returned (wrapped) when rich text translation contains
+// unclosed or mismatched tags (see ParseRichText).
+var ErrRichTextSyntax = errors.New("invalid rich text")
+
+// ErrHTMLElement gets returned (wrapped) when HTML element tag renderer has
+// invalid or unsafe element name, attribute name or URL (see HTMLElement).
+var ErrHTMLElement = errors.New("invalid HTML element")
+
+// ErrFormatSyntax gets returned (wrapped) when translation value contains
+// incomplete fmt verb or bad argument index (see ParseFormatVerbs).
+var ErrFormatSyntax = errors.New("invalid format")
+
+// LanguageError holds information about failure related to specific language.
+// Use errors.Is with ErrLanguageNotFound, ErrLanguageExists,
+// ErrInvalidLanguageTag or ErrInvalidFallback to check failure kind.
+type LanguageError struct {
+	Err      error  // ErrLanguageNotFound, ErrLanguageExists, ErrInvalidLanguageTag or ErrInvalidFallback.
+	Language string // Language keyword as passed by caller.
+	Detail   string // Failure details (optional).
+}
+
+// Error returns error message.
+func (e *LanguageError) Error() string {
+	detail := e.Detail
+
+	if detail == "" {
+		switch e.Err {
+		case ErrLanguageNotFound:
+			detail = "does not exist"
+		case ErrLanguageExists:
+			detail = "already exists"
+		case ErrInvalidLanguageTag:
+			detail = "is not valid language tag"
+		case ErrInvalidFallback:
+			detail = "has invalid fallback"
+		default:
+			detail = fmt.Sprint(e.Err)
+		}
+	}
+
+	return fmt.Sprintf("language '%s' %s", e.Language, detail)
+}
+
+// Unwrap returns failure kind (sentinel error).
+func (e *LanguageError) Unwrap() error {
+	return e.Err
+}
+
+// KeyError holds information about translation key which could not be found.
+// Matches ErrKeyNotFound with errors.Is.
+type KeyError struct {
+	Language string   // Requested language keyword (empty if unknown).
+	Key      string   // Translation keyword/key.
+	Searched []string // Language keywords key was searched in.
+}
+
+// Error returns error message.
+func (e *KeyError) Error() string {
+	if len(e.Searched) > 1 {
+		return fmt.Sprintf("none of languages (%s) contain key '%s'",
+			strings.Join(e.Searched, ", "), e.Key)
+	}
+
+	if e.Language != "" {
+		return fmt.Sprintf("language '%s' does not contain key '%s'", e.Language, e.Key)
+	}
+
+	return fmt.Sprintf("key '%s' does not exist", e.Key)
+}
+
+// Is reports whether target is ErrKeyNotFound.
+func (e *KeyError) Is(target error) bool {
+	return target == ErrKeyNotFound
+}
+
+// YAMLError holds information about YAML translate file loading or parsing
+// failure.
+// Use errors.Is with ErrYAMLRead, ErrYAMLSyntax, ErrYAMLUnsupportedValue,
+// ErrYAMLTooManyPlurals or ErrInvalidLanguageTag to check failure kind.
+type YAMLError struct {
+	File     string // YAML file path (empty if unknown).
+	Key      string // Translation key (empty if not related to specific key).
+	Language string // Language keyword (empty if not related to specific language).
+	Kind     error  // Failure kind (sentinel error).
+	Err      error  // Underlying error (optional).
+}
+
+// Error returns error message.
+func (e *YAMLError) Error() string {
+	builder := strings.Builder{}
+
+	if e.File != "" {
+		builder.WriteString(e.File + ": ")
+	}
+
+	if e.Key != "" {
+		builder.WriteString("'" + e.Key + "'")
+
+		if e.Language != "" {
+			builder.WriteString(" > '" + e.Language + "'")
+		}
+
+		builder.WriteString(": ")
+	}
+
+	builder.WriteString(e.Kind.Error())
+
+	if e.Err != nil {
+		builder.WriteString(": " + e.Err.Error())
+	}
+
+	return builder.String()
+}
+
+// Unwrap returns failure kind and underlying error.
+func (e *YAMLError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+
+	return []error{e.Kind, e.Err}
+}
@@ -0,0 +1,292 @@
+package localization
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"sort"
+)
+
+// FallbackConfig describes explicit language fallback chains and can be
+// applied to Locale with Locale.ApplyFallbackConfig or loaded from YAML file
+// with Locale.LoadFallbackConfig.
+//
+// YAML example:
+//
+//	default: en
+//	fallbacks:
+//	  lv: [ru, en]
+//	  en-GB: [en]
+type FallbackConfig struct {
+	Default   string              `yaml:"default"`   // Global default language (last in every chain).
+	Fallbacks map[string][]string `yaml:"fallbacks"` // Language keyword -> fallback language keywords.
+}
+
+// SetFallback can be used to declare explicit fallback chain for language.
+// Chains are followed transitively, for example, "lv -> ru" and "ru -> en"
+// will result in "lv -> ru -> en". Parent languages are always used before
+// declared chain ("en-GB -> en") and their declared chains are used after it,
+// for example, "en-GB -> fr" and "en -> de" will result in
+// "en-GB -> en -> fr -> de".
+// Once any fallback chain or default language is declared, languages are no longer
+// used as backup in initialization order.
+// Passing no fallback languages removes chain of language.
+// Returns error if language or any of fallback languages does not exist.
+//
+// Params:
+// langKey - target language keyword ("en", "lv" etc).
+// fallback - ordered list of fallback language keywords.
+func (l *Locale) SetFallback(langKey string, fallback ...string) error {
+	keyword, keywords, err := l.resolveFallback(langKey, fallback)
+	if err != nil {
+		return err
+	}
+
+	l.setFallback(keyword, keywords)
+
+	// Fallbacks changed, rebuild fallback chains.
+	l.reindex()
+
+	return nil
+}
+
+// resolveFallback validates fallback chain of language.
+// Returns language keyword and fallback language keywords as initialized or
+// error if any of languages does not exist or language falls back to itself.
+func (l *Locale) resolveFallback(langKey string, fallback []string) (string, []string, error) {
+	lang, err := l.GetLanguage(langKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	keywords := make([]string, 0, len(fallback))
+
+	for _, v := range fallback {
+		fallbackLang, err := l.GetLanguage(v)
+		if err != nil {
+			return "", nil, fmt.Errorf("fallback for '%s': %w", langKey, err)
+		}
+
+		if fallbackLang.Keyword == lang.Keyword {
+			return "", nil, &LanguageError{Err: ErrInvalidFallback, Language: langKey,
+				Detail: "can not fall back to itself"}
+		}
+
+		keywords = append(keywords, fallbackLang.Keyword)
+	}
+
+	return lang.Keyword, keywords, nil
+}
+
+// setFallback stores declared fallback chain of language, empty chain removes
+// it. Does not rebuild index.
+func (l *Locale) setFallback(keyword string, keywords []string) {
+	if l.fallbacks == nil {
+		l.fallbacks = make(map[string][]string)
+	}
+
+	if len(keywords) == 0 {
+		delete(l.fallbacks, keyword)
+		return
+	}
+
+	l.fallbacks[keyword] = keywords
+}
+
+// SetDefaultLanguage can be used to set global default language, which
+// is used as the last fallback for every language.
+// Passing empty string removes default language.
+// Returns error if language does not exist.
+func (l *Locale) SetDefaultLanguage(langKey string) error {
+	keyword := ""
+
+	if langKey != "" {
+		lang, err := l.GetLanguage(langKey)
+		if err != nil {
+			return err
+		}
+
+		keyword = lang.Keyword
+	}
+
+	l.defaultLang = keyword
+
+	// Default language changed, rebuild fallback chains.
+	l.reindex()
+
+	return nil
+}
+
+// DefaultLanguage returns global default language keyword or empty string
+// if default language is not set.
+func (l *Locale) DefaultLanguage() string {
+	return l.defaultLang
+}
+
+// FallbackChain returns ordered list of language keywords which will be
+// searched for translations of passed language, starting with language itself
+// (or its parent language if language does not exist).
+// Respects Locale.StrictUsage.
+// Returns error if language does not exist.
+func (l *Locale) FallbackChain(langKey string) ([]string, error) {
+	index := l.getIndex()
+
+	idx, exist := index.resolve(langKey)
+	if !exist {
+		return nil, &LanguageError{Err: ErrLanguageNotFound, Language: langKey}
+	}
+
+	chain := index.chain(idx, l.StrictUsage)
+
+	keywords := make([]string, len(chain))
+
+	for k, v := range chain {
+		keywords[k] = v.Keyword
+	}
+
+	return keywords, nil
+}
+
+// ApplyFallbackConfig can be used to apply default language and fallback
+// chains from passed FallbackConfig. Empty FallbackConfig.Default keeps
+// current default language. Whole config is validated first, so Locale is
+// not changed if config is not valid.
+// Returns error if any of referenced languages does not exist or language
+// falls back to itself.
+func (l *Locale) ApplyFallbackConfig(config FallbackConfig) error {
+	defaultLang := l.defaultLang
+
+	if config.Default != "" {
+		lang, err := l.GetLanguage(config.Default)
+		if err != nil {
+			return fmt.Errorf("default language: %w", err)
+		}
+
+		defaultLang = lang.Keyword
+	}
+
+	langKeys := make([]string, 0, len(config.Fallbacks))
+	for k := range config.Fallbacks {
+		langKeys = append(langKeys, k)
+	}
+
+	// Sorted, so the same error gets returned for the same config.
+	sort.Strings(langKeys)
+
+	keywords := make([]string, len(langKeys))
+	chains := make([][]string, len(langKeys))
+
+	for k, v := range langKeys {
+		keyword, chain, err := l.resolveFallback(v, config.Fallbacks[v])
+		if err != nil {
+			return err
+		}
+
+		keywords[k], chains[k] = keyword, chain
+	}
+
+	l.defaultLang = defaultLang
+
+	for k, v := range keywords {
+		l.setFallback(v, chains[k])
+	}
+
+	// Fallbacks changed, rebuild fallback chains.
+	l.reindex()
+
+	return nil
+}
+
+// LoadFallbackConfig can be used to load FallbackConfig from YAML file and
+// apply it to current Locale.
+// Requires previous language initialization (Locale.AddLanguages()).
+// Returns error if something went wrong.
+func (l *Locale) LoadFallbackConfig(path string) error {
+	content := newYAMLContent("")
+
+	data, err := content.loadBytes(path)
+	if err != nil {
+		return err
+	}
+
+	config := FallbackConfig{}
+
+	// Unknown fields are not allowed, empty file is empty config.
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	err = decoder.Decode(&config)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return &YAMLError{File: path, Kind: ErrYAMLSyntax, Err: err}
+	}
+
+	err = l.ApplyFallbackConfig(config)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+// hasExplicitFallbacks checks if Locale has declared fallback chains or
+// default language.
+func (l *Locale) hasExplicitFallbacks() bool {
+	return l.defaultLang != "" || len(l.fallbacks) > 0
+}
+
+// buildExplicitFallbackChain is used to build fallback chain for passed language
+// from declared fallback chains and default language: language, its parent
+// languages, declared chain of language, declared chains of parent languages
+// and default language.
+// Passed language will always be as first element in returned slice.
+func (l *Locale) buildExplicitFallbackChain(index *localeIndex, language *Language) []*Language {
+	// Parent languages ("en-US" -> "en") always come first.
+	langs := l.appendParentLanguages(index, []*Language{language}, language)
+	visited := make(map[string]bool, len(langs))
+
+	for _, v := range langs {
+		visited[v.Keyword] = true
+	}
+
+	// appendChain appends declared fallbacks of keyword (transitively).
+	var appendChain func(keyword string)
+
+	appendChain = func(keyword string) {
+		for _, v := range l.fallbacks[keyword] {
+			idx, exist := index.find(v)
+			if !exist || visited[l.Languages[idx].Keyword] {
+				continue
+			}
+
+			fallback := &l.Languages[idx]
+			visited[fallback.Keyword] = true
+			langs = append(langs, fallback)
+
+			appendChain(fallback.Keyword)
+		}
+	}
+
+	// Declared chain of language is followed by declared chains of its
+	// parent languages ("en-GB -> en -> ru").
+	parents := len(langs)
+
+	appendChain(language.Keyword)
+
+	for _, v := range langs[1:parents] {
+		appendChain(v.Keyword)
+	}
+
+	// Default language is always the last one.
+	if l.defaultLang == "" {
+		return langs
+	}
+
+	idx, exist := index.find(l.defaultLang)
+	if exist && !visited[l.Languages[idx].Keyword] {
+		langs = append(langs, &l.Languages[idx])
+	}
+
+	return langs
+}
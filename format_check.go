@@ -0,0 +1,182 @@
+package localization
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FormatIssue holds information about translation value, which fmt verbs
+// differ from source language value of the same key (see
+// Locale.ValidateFormats).
+type FormatIssue struct {
+	Key      string   // Translation keyword/key.
+	Language string   // Language keyword of invalid value.
+	Plural   bool     // Is invalid value plural.
+	Value    string   // Invalid value.
+	Expected string   // Source language value.
+	Problems []string // Problem descriptions ("arg 1 is %s, source has %d").
+	Source   Source   // Invalid value source file and line.
+}
+
+// String returns issue description.
+func (i FormatIssue) String() string {
+	location := ""
+	if i.Source.File != "" {
+		location = fmt.Sprintf("%s:%d: ", i.Source.File, i.Source.Line)
+	}
+
+	kind := ""
+	if i.Plural {
+		kind = " plural"
+	}
+
+	return fmt.Sprintf("%s%s (%s%s): %s", location, i.Key, i.Language, kind, strings.Join(i.Problems, "; "))
+}
+
+// ValidateFormats can be used to find translation values, which fmt verbs
+// (see ParseFormatVerbs) differ in count, type or argument index from the
+// source language value of the same key, for example, source "%d items in %s"
+// and translation "%s: %d". Both non-plural and plural values are checked,
+// plural values are compared with source plural value (non-plural value if
+// source has no plural value). Verbs accepting any type (%v, %T, %x, %X) match
+// any verb.
+// Returns issues sorted by key (then by Locale.Languages order) or error if
+// source language does not exist.
+// Params:
+// sourceLang - source language keyword ("en").
+func (l *Locale) ValidateFormats(sourceLang string) ([]FormatIssue, error) {
+	source, err := l.GetLanguage(sourceLang)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(source.Map))
+	for k := range source.Map {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	issues := make([]FormatIssue, 0)
+
+	for _, key := range keys {
+		expected := source.Map[key]
+
+		for _, language := range l.Languages {
+			if language.Keyword == source.Keyword {
+				continue
+			}
+
+			translation, exist := language.Map[key]
+			if !exist {
+				continue
+			}
+
+			for k, value := range translation {
+				if k == 1 && value == "" {
+					// Plural value is not set.
+					continue
+				}
+
+				expectedValue := expected[k]
+				if k == 1 && expectedValue == "" {
+					expectedValue = expected[0]
+				}
+
+				problems := compareFormats(expectedValue, value)
+				if len(problems) == 0 {
+					continue
+				}
+
+				issues = append(issues, FormatIssue{
+					Key:      key,
+					Language: language.Keyword,
+					Plural:   k == 1,
+					Value:    value,
+					Expected: expectedValue,
+					Problems: problems,
+					Source:   l.sources[sourceKey{language.Keyword, key}],
+				})
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// compareFormats compares fmt verbs of value with verbs of expected value.
+// Returns problem descriptions or nil if verbs match.
+func compareFormats(expected, value string) []string {
+	expectedVerbs, err := ParseFormatVerbs(expected)
+	if err != nil {
+		return []string{"source " + err.Error()}
+	}
+
+	verbs, err := ParseFormatVerbs(value)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	expectedArgs, args := formatArgs(expectedVerbs), formatArgs(verbs)
+	count := max(FormatArgCount(expectedVerbs), FormatArgCount(verbs))
+
+	problems := make([]string, 0)
+
+	for k := 0; k < count; k++ {
+		expectedVerb, inExpected := expectedArgs[k]
+		verb, inValue := args[k]
+
+		switch {
+		case inExpected && !inValue:
+			problems = append(problems, fmt.Sprintf("arg %d (%s) is missing", k+1, expectedVerb.Text))
+		case !inExpected && inValue:
+			problems = append(problems, fmt.Sprintf("arg %d (%s) is not in source", k+1, verb.Text))
+		case inExpected && !verbsCompatible(expectedVerb.Verb, verb.Verb):
+			problems = append(problems, fmt.Sprintf("arg %d is %s, source has %s", k+1, verb.Text, expectedVerb.Text))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return problems
+}
+
+// formatArgs maps argument indexes to the first verb consuming them.
+func formatArgs(verbs []FormatVerb) map[int]FormatVerb {
+	args := make(map[int]FormatVerb, len(verbs))
+
+	for _, v := range verbs {
+		if _, exist := args[v.Arg]; !exist {
+			args[v.Arg] = v
+		}
+	}
+
+	return args
+}
+
+// verbsCompatible checks if verbs accept the same argument type.
+func verbsCompatible(a, b rune) bool {
+	classA, classB := verbClass(a), verbClass(b)
+	return classA == classB || classA == "any" || classB == "any"
+}
+
+// verbClass returns type of argument accepted by verb.
+func verbClass(verb rune) string {
+	switch verb {
+	case 'b', 'c', 'd', 'o', 'O', 'U', '*':
+		return "int"
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		return "float"
+	case 's', 'q':
+		return "string"
+	case 't':
+		return "bool"
+	case 'p':
+		return "pointer"
+	}
+
+	return "any"
+}
@@ -0,0 +1,145 @@
+package localization
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// FormatVerb holds information about fmt verb found in translation value
+// (see ParseFormatVerbs).
+type FormatVerb struct {
+	Text   string // Verb as written ("%-5.2f", "%[2]s").
+	Verb   rune   // Verb character ('d', 's' etc), '*' for width or precision argument.
+	Arg    int    // Index of argument verb consumes (starting from 0).
+	Offset int    // Byte offset of verb in format.
+}
+
+// ParseFormatVerbs can be used to find fmt verbs in translation value, for
+// example, "%[2]s has %d items" -> [{"%[2]s", 's', 1, 0}, {"%d", 'd', 2, 10}].
+// Argument indexes follow fmt rules: explicit indexes ("%[2]d") change index
+// of the following verbs, "*" width and precision consume int argument.
+// Literal percent ("%%") is not returned.
+// Returns verbs or error (ErrFormatSyntax) if verb is incomplete or has bad
+// argument index.
+func ParseFormatVerbs(format string) ([]FormatVerb, error) {
+	verbs := make([]FormatVerb, 0)
+	arg := 0
+
+	for pos := 0; pos < len(format); {
+		start := strings.IndexByte(format[pos:], '%')
+		if start < 0 {
+			break
+		}
+
+		start += pos
+		pos = start + 1
+
+		// Flags.
+		for pos < len(format) && strings.IndexByte("+-# 0", format[pos]) >= 0 {
+			pos++
+		}
+
+		stars := make([]int, 0, 2)
+
+		// Width, precision and verb can be preceded by explicit argument index
+		// ("%[3]*.[2]*[1]f").
+		for part := 0; part < 3; part++ {
+			var err error
+
+			if part == 1 {
+				if pos >= len(format) || format[pos] != '.' {
+					continue
+				}
+
+				pos++
+			}
+
+			pos, arg, err = parseArgIndex(format, start, pos, arg)
+			if err != nil {
+				return nil, err
+			}
+
+			if part == 2 {
+				break
+			}
+
+			if pos < len(format) && format[pos] == '*' {
+				stars = append(stars, arg)
+				arg++
+				pos++
+
+				continue
+			}
+
+			for pos < len(format) && format[pos] >= '0' && format[pos] <= '9' {
+				pos++
+			}
+		}
+
+		if pos >= len(format) {
+			return nil, fmt.Errorf("%w: missing verb at offset %d", ErrFormatSyntax, start)
+		}
+
+		verb, size := utf8.DecodeRuneInString(format[pos:])
+		pos += size
+		text := format[start:pos]
+
+		for _, v := range stars {
+			verbs = append(verbs, FormatVerb{Text: text, Verb: '*', Arg: v, Offset: start})
+		}
+
+		if verb == '%' {
+			continue
+		}
+
+		verbs = append(verbs, FormatVerb{Text: text, Verb: verb, Arg: arg, Offset: start})
+		arg++
+	}
+
+	return verbs, nil
+}
+
+// FormatArgCount returns count of arguments required by verbs (the highest
+// argument index + 1).
+func FormatArgCount(verbs []FormatVerb) int {
+	count := 0
+
+	for _, v := range verbs {
+		if v.Arg+1 > count {
+			count = v.Arg + 1
+		}
+	}
+
+	return count
+}
+
+// parseArgIndex parses explicit argument index ("[2]") at pos.
+// Returns position after index, argument index (unchanged if there is no
+// explicit index) or error if index is invalid.
+func parseArgIndex(format string, start, pos, arg int) (int, int, error) {
+	if pos >= len(format) || format[pos] != '[' {
+		return pos, arg, nil
+	}
+
+	end := strings.IndexByte(format[pos:], ']')
+	if end < 0 {
+		return 0, 0, fmt.Errorf("%w: unclosed argument index at offset %d", ErrFormatSyntax, start)
+	}
+
+	index := 0
+
+	for _, v := range format[pos+1 : pos+end] {
+		if v < '0' || v > '9' || index > 1000 {
+			return 0, 0, fmt.Errorf("%w: bad argument index at offset %d", ErrFormatSyntax, start)
+		}
+
+		index = index*10 + int(v-'0')
+	}
+
+	if index < 1 {
+		return 0, 0, fmt.Errorf("%w: bad argument index at offset %d", ErrFormatSyntax, start)
+	}
+
+	return pos + end + 1, index - 1, nil
+}
@@ -0,0 +1,150 @@
+package localization
+
+import (
+	"fmt"
+)
+
+// FuncMap can be used to get template functions bound to Locale default
+// language (see Locale.SetDefaultLanguage) or the first initialized language.
+// Functions are the same as Localizer.FuncMap functions. Use it at template
+// parse time and replace functions with request Localizer.FuncMap before
+// execution:
+//
+//	base := template.Must(template.New("page").Funcs(locale.FuncMap()).Parse(page))
+//
+//	// For each request.
+//	tmpl := template.Must(base.Clone()).Funcs(locale.For(lang).FuncMap())
+//
+// Returned map can be passed to both html/template and text/template Funcs.
+func (l *Locale) FuncMap() map[string]interface{} {
+	return l.For().FuncMap()
+}
+
+// FuncMap can be used to get template functions bound to Localizer language:
+//
+//	t <key> - non-plural translation ({{ t "hello" }}).
+//	tf <key> <args...> - formatted non-plural translation ({{ tf "hello_name" .Name }}).
+//	tn <key> <count> <args...> - plural translation if count is greater than 1, formatted with count and args ({{ tn "items" .Count }}).
+//	tnamed <key> <name> <value>... - translation with named placeholders ({{ tnamed "greeting" "name" .Name }}).
+//	trich <tags> <name> <value>... - rich text translation rendered as HTML (see Localizer.RichHTML).
+//	richtags <tag> <RichTag>... - rich text tag renderers map for trich.
+//	richel <element> <attribute> <value>... - HTML element tag renderer (see HTMLElement, URLs are checked with Locale.HTMLPolicy).
+//	tlang - Localizer language keyword (<html lang="{{ tlang }}">).
+//
+// Rich text example, "terms" translation is "Read <link>terms</link>":
+//
+//	{{ trich "terms" (richtags "link" (richel "a" "href" .TermsURL)) }}
+//
+// Translations of keys with HTMLKeySuffix ("terms_html") are returned as
+// sanitized template.HTML with escaped args (see Localizer.HTML), other
+// translations are returned as strings (escaped by html/template).
+// Missing keys are handled by Locale.MissingBehavior, with MissingAsError
+// template execution fails with KeyError.
+// Returned map can be passed to both html/template and text/template Funcs.
+func (lz Localizer) FuncMap() map[string]interface{} {
+	return map[string]interface{}{
+		"t": func(key string) (interface{}, error) {
+			if isHTMLKey(key) {
+				return lz.templateResult(lz.html(key))
+			}
+
+			return lz.templateResult(lz.t(key))
+		},
+		"tf": func(key string, args ...interface{}) (interface{}, error) {
+			if isHTMLKey(key) {
+				return lz.templateResult(lz.html(key, args...))
+			}
+
+			return lz.templateResult(lz.tf(key, args...))
+		},
+		"tn": func(key string, count int, args ...interface{}) (interface{}, error) {
+			if isHTMLKey(key) {
+				return lz.templateResult(lz.htmlPlural(key, count, args...))
+			}
+
+			return lz.templateResult(lz.tn(key, count, args...))
+		},
+		"tnamed": func(key string, pairs ...interface{}) (interface{}, error) {
+			args, err := namedArgs(pairs)
+			if err != nil {
+				return "", err
+			}
+
+			if isHTMLKey(key) {
+				return lz.templateResult(lz.htmlNamed(key, args))
+			}
+
+			return lz.templateResult(lz.tnamed(key, args))
+		},
+		"trich": func(key string, tags map[string]RichTag, pairs ...interface{}) (interface{}, error) {
+			args, err := namedArgs(pairs)
+			if err != nil {
+				return "", err
+			}
+
+			return lz.templateResult(lz.richHTML(key, tags, args))
+		},
+		"richtags": richTags,
+		"richel": func(name string, attributes ...string) (RichTag, error) {
+			return lz.htmlPolicy().element(name, attributes...)
+		},
+		"tlang": lz.Language,
+	}
+}
+
+// templateResult returns lookup error only if Locale.MissingBehavior is
+// MissingAsError, so template execution fails only when configured.
+func (lz Localizer) templateResult(text interface{}, err error) (interface{}, error) {
+	if err == nil || lz.locale == nil || lz.locale.MissingBehavior != MissingAsError {
+		return text, nil
+	}
+
+	return text, err
+}
+
+// richTags converts tag name and RichTag pairs into tags map.
+// Returns error if pairs count is odd or types are invalid.
+func richTags(pairs ...interface{}) (map[string]RichTag, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("rich tags must be name and tag pairs, got %d values", len(pairs))
+	}
+
+	tags := make(map[string]RichTag, len(pairs)/2)
+
+	for k := 0; k < len(pairs); k += 2 {
+		name, ok := pairs[k].(string)
+		if !ok {
+			return nil, fmt.Errorf("rich tag name must be string, got %T", pairs[k])
+		}
+
+		tag, ok := pairs[k+1].(RichTag)
+		if !ok {
+			return nil, fmt.Errorf("rich tag '%s' must be RichTag, got %T", name, pairs[k+1])
+		}
+
+		tags[name] = tag
+	}
+
+	return tags, nil
+}
+
+// namedArgs converts name and value pairs into named args map.
+// Returns error if pairs count is odd or name is not a string.
+func namedArgs(pairs []interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("named args must be name and value pairs, got %d values", len(pairs))
+	}
+
+	args := make(map[string]interface{}, len(pairs)/2)
+
+	for k := 0; k < len(pairs); k += 2 {
+		name, ok := pairs[k].(string)
+		if !ok {
+			return nil, fmt.Errorf("named arg name must be string, got %T", pairs[k])
+		}
+
+		args[name] = pairs[k+1]
+	}
+
+	return args, nil
+}
@@ -2,6 +2,12 @@ module github.com/gaigals/localization
 
 go 1.23.3
 
-require gopkg.in/yaml.v2 v2.4.0
+require gopkg.in/yaml.v3 v3.0.1
 
-require github.com/spf13/cast v1.7.0
+require golang.org/x/net v0.43.0
+
+require (
+	golang.org/x/mod v0.27.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
+	golang.org/x/tools v0.36.0
+)
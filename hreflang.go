@@ -0,0 +1,143 @@
+package localization
+
+import (
+	"encoding/xml"
+	"html/template"
+	"strings"
+)
+
+// XDefault is hreflang value for page shown when none of languages match
+// user language.
+const XDefault = "x-default"
+
+// Alternate holds information about page in specific language, used for
+// hreflang links.
+type Alternate struct {
+	Hreflang string // BCP 47 language tag ("lv", "en-GB") or "x-default".
+	URL      string // Absolute page URL.
+}
+
+// Alternates holds page alternates for all enabled languages (see
+// Router.Alternates).
+type Alternates []Alternate
+
+// Alternates can be used to build page alternates for all enabled languages
+// plus "x-default" (default language, see Locale.SetDefaultLanguage) using
+// Router URL scheme. Each hreflang value is used once: if languages differ
+// only in variants ("de-CH-1996", "de-CH"), language matching hreflang value
+// exactly or the first of them is used.
+//
+// Params:
+// baseURL - site scheme and host, for example, "https://example.com".
+// path - canonical page path without language prefix, for example, "/contact".
+func (rt *Router) Alternates(baseURL, path string) Alternates {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	alternates := make(Alternates, 0, len(rt.Locale.Languages)+1)
+
+	// Languages differing only in variants share hreflang value, language
+	// matching hreflang exactly wins, otherwise the first one is used.
+	indexes := make(map[string]int, len(rt.Locale.Languages))
+	exact := make(map[string]bool, len(rt.Locale.Languages))
+
+	for _, v := range rt.Locale.Languages {
+		alternate := Alternate{
+			Hreflang: hreflang(v.Keyword),
+			URL:      baseURL + rt.URL(v.Keyword, path),
+		}
+		isExact := normalizeKeyword(v.Keyword) == alternate.Hreflang
+
+		idx, exist := indexes[alternate.Hreflang]
+		if !exist {
+			indexes[alternate.Hreflang] = len(alternates)
+			exact[alternate.Hreflang] = isExact
+			alternates = append(alternates, alternate)
+
+			continue
+		}
+
+		if isExact && !exact[alternate.Hreflang] {
+			alternates[idx] = alternate
+			exact[alternate.Hreflang] = true
+		}
+	}
+
+	defaultLang := rt.Locale.fallbackLanguage()
+	if defaultLang != "" {
+		alternates = append(alternates, Alternate{
+			Hreflang: XDefault,
+			URL:      baseURL + rt.URL(defaultLang, path),
+		})
+	}
+
+	return alternates
+}
+
+// HTML returns HTML link tags, one per line, for example:
+//
+//	<link rel="alternate" hreflang="lv" href="https://example.com/lv/kontakti">
+func (a Alternates) HTML() template.HTML {
+	builder := strings.Builder{}
+
+	for _, v := range a {
+		builder.WriteString(`<link rel="alternate" hreflang="`)
+		builder.WriteString(template.HTMLEscapeString(v.Hreflang))
+		builder.WriteString(`" href="`)
+		builder.WriteString(template.HTMLEscapeString(v.URL))
+		builder.WriteString("\">\n")
+	}
+
+	return template.HTML(builder.String())
+}
+
+// SitemapXML returns sitemap xhtml:link entries, one per line, for example:
+//
+//	<xhtml:link rel="alternate" hreflang="lv" href="https://example.com/lv/kontakti"/>
+//
+// Sitemap urlset must declare namespace
+// xmlns:xhtml="http://www.w3.org/1999/xhtml".
+func (a Alternates) SitemapXML() string {
+	builder := strings.Builder{}
+
+	for _, v := range a {
+		builder.WriteString(`<xhtml:link rel="alternate" hreflang="`)
+		_ = xml.EscapeText(&builder, []byte(v.Hreflang))
+		builder.WriteString(`" href="`)
+		_ = xml.EscapeText(&builder, []byte(v.URL))
+		builder.WriteString("\"/>\n")
+	}
+
+	return builder.String()
+}
+
+// FuncMap returns html/template functions for hreflang links:
+//
+//	hreflang <path> - HTML link tags for canonical page path ({{ hreflang "/contact" }}).
+//	alternates <path> - Alternates for canonical page path.
+//
+// Params:
+// baseURL - site scheme and host, for example, "https://example.com".
+func (rt *Router) FuncMap(baseURL string) template.FuncMap {
+	return template.FuncMap{
+		"hreflang": func(path string) template.HTML {
+			return rt.Alternates(baseURL, path).HTML()
+		},
+		"alternates": func(path string) Alternates {
+			return rt.Alternates(baseURL, path)
+		},
+	}
+}
+
+// hreflang returns hreflang value for language keyword. Only language,
+// script and region subtags are supported by hreflang, so variants and
+// extensions are removed ("de-CH-1996" -> "de-CH").
+func hreflang(keyword string) string {
+	tag, err := ParseTag(keyword)
+	if err != nil || tag.Language == "" {
+		return keyword
+	}
+
+	tag.Variants = nil
+	tag.Extensions = nil
+
+	return tag.String()
+}
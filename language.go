@@ -1,7 +1,5 @@
 package localization
 
-import "fmt"
-
 // TextMap is typedef for map which contains non-plural and plural
 // translation. First element will always be non-plural.
 type TextMap map[string][2]string
@@ -21,11 +19,11 @@ func (l *Language) ValueNoErr(key string) string {
 
 // Value can be used to extract non-plural translation from language
 // by providing translation keyword/key.
-// Returns error if key does not exist.
+// Returns KeyError (ErrKeyNotFound) if key does not exist.
 func (l *Language) Value(key string) (string, error) {
 	_, exist := l.Map[key]
 	if !exist {
-		return "", fmt.Errorf("key '%s' does not exist", key)
+		return "", &KeyError{Language: l.Keyword, Key: key}
 	}
 
 	return l.ValueNoErr(key), nil
@@ -40,11 +38,11 @@ func (l *Language) ValuePluralNoErr(key string) string {
 
 // ValuePlural can be used to extract plural translation from language
 // by providing translation keyword/key.
-// Returns error if key does not exist.
+// Returns KeyError (ErrKeyNotFound) if key does not exist.
 func (l *Language) ValuePlural(key string) (string, error) {
 	_, exist := l.Map[key]
 	if !exist {
-		return "", fmt.Errorf("key '%s' does not exist", key)
+		return "", &KeyError{Language: l.Keyword, Key: key}
 	}
 
 	return l.ValuePluralNoErr(key), nil
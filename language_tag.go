@@ -0,0 +1,241 @@
+package localization
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tag holds parsed and canonicalized BCP 47 language tag subtags, for example,
+// "zh-Hant-TW" -> Language: "zh", Script: "Hant", Region: "TW".
+// Use ParseTag to parse language tags.
+//
+// Docs:
+// https://www.rfc-editor.org/rfc/rfc5646
+type Tag struct {
+	Language   string   // Primary language subtag with extended language subtags ("en", "zh-yue").
+	Script     string   // Script subtag in title case ("Latn", "Hant"), optional.
+	Region     string   // Region subtag in upper case ("US") or digits ("419"), optional.
+	Variants   []string // Variant subtags in lower case ("1996"), optional.
+	Extensions []string // Extension and private use subtags in lower case ("u-ca-buddhist"), optional.
+}
+
+// ParseTag can be used to parse and validate BCP 47 language tag. Both "-"
+// and "_" are accepted as subtag separators, so "pt_BR" and "pt-BR" result
+// in the same Tag.
+// Returns parsed Tag or LanguageError (ErrInvalidLanguageTag) if value is not
+// valid language tag.
+func ParseTag(value string) (Tag, error) {
+	tag := Tag{}
+
+	if value == "" {
+		return tag, invalidTagError(value, "is empty")
+	}
+
+	subtags := strings.Split(strings.ReplaceAll(value, "_", "-"), "-")
+
+	for _, v := range subtags {
+		if len(v) == 0 || len(v) > 8 || !isAlphaNum(v) {
+			return Tag{}, invalidTagError(value, "contains invalid subtag '%s'", v)
+		}
+	}
+
+	// Private use tag ("x-whatever").
+	if strings.EqualFold(subtags[0], "x") {
+		return tag.parseExtensions(value, subtags)
+	}
+
+	// Primary language subtag.
+	primary := subtags[0]
+	if len(primary) < 2 || len(primary) == 4 || !isAlpha(primary) {
+		return Tag{}, invalidTagError(value, "contains invalid language subtag '%s'", primary)
+	}
+
+	tag.Language = strings.ToLower(primary)
+	subtags = subtags[1:]
+
+	// Extended language subtags (up to 3), only after 2-3 letter language.
+	for count := 0; count < 3 && len(primary) <= 3 && len(subtags) > 0; count++ {
+		if len(subtags[0]) != 3 || !isAlpha(subtags[0]) {
+			break
+		}
+
+		tag.Language += "-" + strings.ToLower(subtags[0])
+		subtags = subtags[1:]
+	}
+
+	// Script subtag.
+	if len(subtags) > 0 && len(subtags[0]) == 4 && isAlpha(subtags[0]) {
+		tag.Script = strings.ToUpper(subtags[0][:1]) + strings.ToLower(subtags[0][1:])
+		subtags = subtags[1:]
+	}
+
+	// Region subtag.
+	if len(subtags) > 0 && isRegionSubtag(subtags[0]) {
+		tag.Region = strings.ToUpper(subtags[0])
+		subtags = subtags[1:]
+	}
+
+	// Variant subtags.
+	for len(subtags) > 0 && isVariantSubtag(subtags[0]) {
+		variant := strings.ToLower(subtags[0])
+
+		for _, v := range tag.Variants {
+			if v == variant {
+				return Tag{}, invalidTagError(value, "contains repeated variant '%s'", variant)
+			}
+		}
+
+		tag.Variants = append(tag.Variants, variant)
+		subtags = subtags[1:]
+	}
+
+	return tag.parseExtensions(value, subtags)
+}
+
+// CanonicalTag can be used to validate and canonicalize BCP 47 language tag,
+// for example, "EN_us" -> "en-US", "zh-hant-tw" -> "zh-Hant-TW".
+// Returns canonical tag or error if value is not valid language tag.
+func CanonicalTag(value string) (string, error) {
+	tag, err := ParseTag(value)
+	if err != nil {
+		return "", err
+	}
+
+	return tag.String(), nil
+}
+
+// String returns canonical language tag.
+func (t Tag) String() string {
+	subtags := make([]string, 0, 3+len(t.Variants)+len(t.Extensions))
+
+	if t.Language != "" {
+		subtags = append(subtags, t.Language)
+	}
+
+	if t.Script != "" {
+		subtags = append(subtags, t.Script)
+	}
+
+	if t.Region != "" {
+		subtags = append(subtags, t.Region)
+	}
+
+	subtags = append(subtags, t.Variants...)
+	subtags = append(subtags, t.Extensions...)
+
+	return strings.Join(subtags, "-")
+}
+
+// Parent returns parent language tag by removing the most specific subtag,
+// for example, "zh-Hant-TW" -> "zh-Hant" -> "zh".
+// Returns false if tag has no parent.
+func (t Tag) Parent() (Tag, bool) {
+	parent := t
+
+	switch {
+	case len(t.Extensions) > 0:
+		parent.Extensions = nil
+	case len(t.Variants) > 0:
+		parent.Variants = t.Variants[:len(t.Variants)-1]
+	case t.Region != "":
+		parent.Region = ""
+	case t.Script != "":
+		parent.Script = ""
+	default:
+		return Tag{}, false
+	}
+
+	if len(parent.Variants) == 0 {
+		parent.Variants = nil
+	}
+
+	return parent, parent.Language != ""
+}
+
+// parseExtensions parses remaining extension and private use subtags.
+// Returns final Tag or error if subtags are not valid.
+func (t Tag) parseExtensions(value string, subtags []string) (Tag, error) {
+	for len(subtags) > 0 {
+		singleton := strings.ToLower(subtags[0])
+		if len(singleton) != 1 {
+			return Tag{}, invalidTagError(value, "contains invalid subtag '%s'", subtags[0])
+		}
+
+		// Private use consumes all remaining subtags.
+		end := len(subtags)
+
+		if singleton != "x" {
+			end = 1
+			for end < len(subtags) && len(subtags[end]) > 1 {
+				end++
+			}
+
+			if end == 1 {
+				return Tag{}, invalidTagError(value, "contains empty extension '%s'", singleton)
+			}
+		}
+
+		if end == 1 {
+			return Tag{}, invalidTagError(value, "contains empty private use subtag")
+		}
+
+		t.Extensions = append(t.Extensions, strings.ToLower(strings.Join(subtags[:end], "-")))
+		subtags = subtags[end:]
+	}
+
+	return t, nil
+}
+
+// invalidTagError constructs LanguageError for not valid language tag.
+func invalidTagError(value, format string, args ...interface{}) error {
+	return &LanguageError{Err: ErrInvalidLanguageTag, Language: value, Detail: fmt.Sprintf(format, args...)}
+}
+
+// isRegionSubtag checks if subtag is region subtag (2 letters or 3 digits).
+func isRegionSubtag(subtag string) bool {
+	return (len(subtag) == 2 && isAlpha(subtag)) || (len(subtag) == 3 && isDigit(subtag))
+}
+
+// isVariantSubtag checks if subtag is variant subtag (5-8 characters or
+// 4 characters starting with digit).
+func isVariantSubtag(subtag string) bool {
+	if len(subtag) >= 5 {
+		return true
+	}
+
+	return len(subtag) == 4 && subtag[0] >= '0' && subtag[0] <= '9'
+}
+
+// isAlpha checks if value contains only ASCII letters.
+func isAlpha(value string) bool {
+	for i := 0; i < len(value); i++ {
+		c := value[i] | 0x20
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isDigit checks if value contains only ASCII digits.
+func isDigit(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isAlphaNum checks if value contains only ASCII letters and digits.
+func isAlphaNum(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if !isAlpha(value[i:i+1]) && !isDigit(value[i:i+1]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -4,15 +4,25 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 )
 
 // Locale contains all initialized languages and can be used for handling
 // localization/translation. Each Locale.Language represents translation
 // layer and each layer contains initialized translation keywords/keys.
+// Language keywords must not be changed in place (Locale.Languages[0].Keyword)
+// after Locale is created, replace Locale.Languages slice instead.
 type Locale struct {
 	Languages   []Language // List of initialized languages.
 	StrictUsage bool       // Is other language usage allowed if key does not exist for given lang.
+
+	OnMissingKey    MissingKeyHandler // Called when translation key can not be found (optional).
+	MissingBehavior MissingBehavior   // Localizer and template function result for missing keys.
+	HTMLPolicy      *HTMLPolicy       // Sanitizer for HTML keys (see HTMLKeySuffix), DefaultHTMLPolicy if nil.
+
+	defaultLang string               // Global default language (see Locale.SetDefaultLanguage).
+	fallbacks   map[string][]string  // Declared fallback chains (see Locale.SetFallback).
+	sources     map[sourceKey]Source // Translation sources (see Locale.Lookup).
+	index       *localeIndex         // Keyword lookup table and fallback chains (see Locale.getIndex).
 }
 
 // NewLocale can be used to initialize new Locale structure with provided languages.
@@ -67,6 +77,8 @@ func (l *Locale) GlobalYAMLLoad(defaultLang, pattern string) error {
 }
 
 // AddLanguages can be used to add new languages to Locale.
+// Language keywords must be valid BCP 47 language tags and are stored in
+// canonical form ("en_us" -> "en-US", see CanonicalTag).
 // Returns error if something went wrong.
 // Params:
 // lang - list of languages keywords to initialize ("en", "lv" etc).
@@ -75,21 +87,34 @@ func (l *Locale) AddLanguages(lang ...string) error {
 		return nil
 	}
 
-	languages := make([]Language, len(lang))
+	// Validate and canonicalize language tags ("en_us" -> "en-US").
+	keywords := make([]string, len(lang))
 
 	for k, v := range lang {
+		keyword, err := CanonicalTag(v)
+		if err != nil {
+			return err
+		}
+
+		keywords[k] = keyword
+	}
+
+	languages := make([]Language, len(lang))
+
+	for k, v := range keywords {
 		// Check if language keyword already is initialized (important to avoid future bugs).
 		// err == nil if language with keyword exists.
 		_, err := l.GetLanguage(v)
 		if err == nil {
-			return fmt.Errorf("language '%s' already exists", v)
+			return &LanguageError{Err: ErrLanguageExists, Language: lang[k]}
 		}
 
 		// Check if lang param element is unique (important to avoid future bugs).
 		// Will return error if param 'lang' contains []string{"en", "lv", "lv"}.
-		count := l.countLangSliceEntries(v, lang)
+		count := l.countLangSliceEntries(v, keywords)
 		if count != 1 {
-			return fmt.Errorf("language '%s' redefined in passed lang parameter", v)
+			return &LanguageError{Err: ErrLanguageExists, Language: lang[k],
+				Detail: "redefined in passed lang parameter"}
 		}
 
 		language := &languages[k]
@@ -100,13 +125,19 @@ func (l *Locale) AddLanguages(lang ...string) error {
 
 	l.Languages = append(l.Languages, languages...)
 
+	// Languages changed, rebuild lookup table and fallback chains.
+	l.reindex()
+
 	return nil
 }
 
 // Value can be used to extract non-plural translation from target language
 // by providing translation keyword/key. If Locale.StrictUsage is FALSE then
 // other languages will be used as backup for searching keyword/key.
-// Returns non-plural value or error if langKey does not exist, or key does not exist.
+// If target language does not exist, then its parent language is used
+// ("en-US" -> "en").
+// Returns non-plural value or error if langKey does not exist (LanguageError), or
+// key does not exist (KeyError).
 //
 // Params:
 // langKey - target language keyword ("en", "lv" etc).
@@ -132,7 +163,8 @@ func (l *Locale) ValueNoErr(langKey, textKey string) string {
 // ValuePlural can be used to extract plural translation from target language
 // by providing translation keyword/key. If Locale.StrictUsage is FALSE then
 // other languages will be used as backup for searching keyword/key.
-// Returns plural value or error if langKey does not exist, or key does not exist.
+// Returns plural value or error if langKey does not exist (LanguageError), or
+// key does not exist (KeyError).
 //
 // Params:
 // langKey - target language keyword ("en", "lv" etc).
@@ -159,7 +191,8 @@ func (l *Locale) ValuePluralNoErr(langKey, textKey string) string {
 // _value is helper method which can be used to extract plural translation from
 // target language by providing translation keyword/key.
 // If Locale.StrictUsage is FALSE then
-// other languages will be used as backup for searching keyword/key.
+// other languages will be used as backup for searching keyword/key, otherwise
+// only parent languages are used ("en-GB" -> "en").
 // Returns plural or non-plural value or error if langKey does not exist,
 // or key does not exist.
 //
@@ -168,31 +201,54 @@ func (l *Locale) ValuePluralNoErr(langKey, textKey string) string {
 // textKey - translation keyword/key.
 // isPlural - find plural translation value.
 func (l *Locale) _value(langKey, textKey string, isPlural bool) (string, error) {
-	lang, err := l.GetLanguage(langKey)
-	if err != nil {
-		return "", err
+	text, _, _, err := l.search(langKey, textKey, isPlural)
+	return text, err
+}
+
+// search is used to find plural or non-plural translation by following target
+// language fallback chain.
+// Returns translation, language translation came from and whether it's other
+// language than requested, or error if langKey does not exist, or key does not exist.
+func (l *Locale) search(langKey, textKey string, isPlural bool) (string, *Language, bool, error) {
+	index := l.getIndex()
+
+	idx, exact := index.find(langKey)
+	if !exact {
+		var exist bool
+
+		idx, exist = index.findParent(langKey)
+		if !exist {
+			return "", nil, false, &LanguageError{Err: ErrLanguageNotFound, Language: langKey}
+		}
 	}
 
-	langList := l.buildPrioritizedLanguageList(lang)
-	text := ""
+	chain := index.chain(idx, l.StrictUsage)
 
-	for k := range langList {
-		if !isPlural {
-			text, err = langList[k].Value(textKey)
-		} else {
-			text, err = langList[k].ValuePlural(textKey)
+	for k, v := range chain {
+		translation, exist := v.Map[textKey]
+		if !exist {
+			continue
 		}
 
-		if err == nil {
-			return text, nil
+		// Requested language itself is always the first one in chain.
+		fallback := k != 0 || !exact
+
+		if isPlural {
+			return translation[1], v, fallback, nil
 		}
+
+		return translation[0], v, fallback, nil
 	}
 
-	if l.StrictUsage {
-		return "", fmt.Errorf("language '%s' does not contain key '%s'", langKey, textKey)
+	// Let handler know about missing key, as NoErr variants discard errors.
+	l.reportMissingKey(langKey, textKey, isPlural)
+
+	searched := make([]string, len(chain))
+	for k, v := range chain {
+		searched[k] = v.Keyword
 	}
 
-	return "", fmt.Errorf("non of languages contain key '%s'", textKey)
+	return "", nil, false, &KeyError{Language: langKey, Key: textKey, Searched: searched}
 }
 
 // SetValue can be used to set translation plural and non-plural values for target
@@ -205,7 +261,8 @@ func (l *Locale) _value(langKey, textKey string, isPlural bool) (string, error)
 // plural - plural value.
 func (l *Locale) SetValue(langKey, textKey, value, plural string) error {
 	if len(l.Languages) == 0 {
-		return fmt.Errorf("language list is empty")
+		return &LanguageError{Err: ErrLanguageNotFound, Language: langKey,
+			Detail: "does not exist, language list is empty"}
 	}
 
 	lang, err := l.GetLanguage(langKey)
@@ -214,6 +271,8 @@ func (l *Locale) SetValue(langKey, textKey, value, plural string) error {
 	}
 
 	lang.SetValue(textKey, value, plural)
+	// Manually set value has no source.
+	l.setSource(lang.Keyword, textKey, Source{})
 
 	return nil
 }
@@ -230,19 +289,19 @@ func (l *Locale) SetValue(langKey, textKey, value, plural string) error {
 func (l *Locale) SetValueNoErr(langKey, textKey, value, plural string) {
 	lang, _ := l.GetLanguage(langKey)
 	lang.SetValue(textKey, value, plural)
+	l.setSource(lang.Keyword, textKey, Source{})
 }
 
 // GetLanguage can be used to get language with specific keyword ("en", "lv" etc).
-// Returns pointer to target language or error if language with provided keyword
-// does not exist
+// Returns pointer to target language or LanguageError (ErrLanguageNotFound) if
+// language with provided keyword does not exist
 func (l *Locale) GetLanguage(langKey string) (*Language, error) {
-	for k, v := range l.Languages {
-		if strings.EqualFold(langKey, v.Keyword) {
-			return &l.Languages[k], nil
-		}
+	idx, exist := l.getIndex().find(langKey)
+	if exist {
+		return &l.Languages[idx], nil
 	}
 
-	return nil, fmt.Errorf("language '%s' does not exist", langKey)
+	return nil, &LanguageError{Err: ErrLanguageNotFound, Language: langKey}
 }
 
 // AddTranslate can be used to add 1 or more translations to current Locale.
@@ -280,6 +339,12 @@ func (l *Locale) AddYAMLFile(files ...*YAMLFile) error {
 		if err != nil {
 			return fmt.Errorf("'%s': %w", v.FilePath, err)
 		}
+
+		// Remember where translations came from (see Locale.Lookup).
+		for _, y := range v.Translates {
+			lang, _ := l.GetLanguage(y.Language)
+			l.setSource(lang.Keyword, y.Key, Source{File: v.FilePath, Line: y.Line})
+		}
 	}
 
 	return nil
@@ -305,41 +370,90 @@ func (l *Locale) LoadYAMLFile(defaultLanguage string, filePath ...string) error
 	return l.AddYAMLFile(yamlFiles...)
 }
 
-// buildPrioritizedLanguageList is used to build prioritized list of Languages
-// for searching plural and non-plural values.
-// If Locale.StrictUsage is TRUE then method will return slice of passed language as
-// other language backups are restricted.
-// If Locale.StrictUsage is FALSE then method will return slice of prioritized languages.
-// Either case, passed language will always be as first element in returned slice.
-func (l *Locale) buildPrioritizedLanguageList(language *Language) []*Language {
-	// If StrictUsage is enabled then return slice with passed language (no backups
-	// allowed).
-	if l.StrictUsage {
-		return []*Language{language}
+// buildFallbackChain is used to build fallback chain for passed language,
+// ignoring Locale.StrictUsage. Passed language will always be as first element
+// in returned slice.
+// If Locale has declared fallback chains or default language, then they are
+// used (see Locale.SetFallback), otherwise passed language is followed by
+// other languages in initialization order.
+func (l *Locale) buildFallbackChain(index *localeIndex, language *Language) []*Language {
+	if l.hasExplicitFallbacks() {
+		return l.buildExplicitFallbackChain(index, language)
 	}
 
 	// Create new slice.
-	langs := make([]*Language, len(l.Languages))
-	// Add passed language as first element in slice (first priority).
-	langs[0] = language
+	langs := make([]*Language, 0, len(l.Languages))
+	// Add passed language as first element in slice (first priority) followed by
+	// its parent languages ("en-US" -> "en").
+	langs = append(langs, language)
+	langs = l.appendParentLanguages(index, langs, language)
+
+	for k := range l.Languages {
+		// If language is already in slice then continue.
+		if containsLanguage(langs, &l.Languages[k]) {
+			continue
+		}
+
+		// Apply current language to slice.
+		langs = append(langs, &l.Languages[k])
+	}
+
+	return langs
+}
 
-	// Set slice index. Start from 1 (0 is already reserved).
-	idx := 1
+// appendParentLanguages appends initialized parent languages of passed
+// language to langs ("zh-Hant-TW" -> "zh-Hant" -> "zh").
+// Returns modified langs slice.
+func (l *Locale) appendParentLanguages(index *localeIndex, langs []*Language, language *Language) []*Language {
+	tag, err := ParseTag(language.Keyword)
+	if err != nil {
+		return langs
+	}
 
-	for k, v := range l.Languages {
-		// If this is same language as passed one then continue.
-		if v.Keyword == language.Keyword {
+	for parent, ok := tag.Parent(); ok; parent, ok = parent.Parent() {
+		idx, exist := index.find(parent.String())
+		if !exist || containsLanguage(langs, &l.Languages[idx]) {
 			continue
 		}
 
-		// Apply current language to slice.
-		langs[idx] = &l.Languages[k]
-		idx++
+		langs = append(langs, &l.Languages[idx])
 	}
 
 	return langs
 }
 
+// containsLanguage checks if langs contains passed language.
+func containsLanguage(langs []*Language, language *Language) bool {
+	for _, v := range langs {
+		if v == language {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reindex rebuilds Locale language lookup table and fallback chains.
+// Must be called after every Locale.Languages, fallback chain or default
+// language modification made by Locale methods.
+func (l *Locale) reindex() {
+	l.index = newLocaleIndex(l)
+}
+
+// getIndex returns Locale language lookup table and fallback chains.
+// Lookups never modify Locale, so they are safe for concurrent use: if
+// Locale was created without NewLocale or Locale.Languages was modified
+// directly (bypassing Locale methods), then lazy index (linear search, chain
+// built on demand) is returned without storing it.
+func (l *Locale) getIndex() *localeIndex {
+	index := l.index
+	if index == nil || !index.isValid(l.Languages) {
+		return newLazyLocaleIndex(l)
+	}
+
+	return index
+}
+
 // countLangSliceEntries is used as helper for Locale.AddLanguages() to check if
 // provided lang keyword repeats in given slice.
 // Returns count of keyword in given slice.
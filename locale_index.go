@@ -0,0 +1,167 @@
+package localization
+
+import "strings"
+
+// localeIndex holds precomputed lookup data for Locale languages, so
+// translation lookups do not have to scan or allocate.
+// Index is bound to specific Locale.Languages slice and is built by Locale
+// methods modifying languages or fallback chains (see Locale.reindex and
+// Locale.getIndex).
+type localeIndex struct {
+	languages []Language     // Languages slice index was built for.
+	keys      map[string]int // Keyword (as is and normalized) -> Locale.Languages index.
+	chains    [][]*Language  // Fallback chain for each language (language itself first).
+	strict    [][]*Language  // Chain for each language used with Locale.StrictUsage (language and its parents).
+	locale    *Locale        // Locale chains are built for on demand, only for lazy index (see newLazyLocaleIndex).
+}
+
+// newLocaleIndex builds new localeIndex for passed Locale.
+func newLocaleIndex(l *Locale) *localeIndex {
+	index := localeIndex{
+		languages: l.Languages,
+		keys:      make(map[string]int, len(l.Languages)*2),
+		chains:    make([][]*Language, len(l.Languages)),
+		strict:    make([][]*Language, len(l.Languages)),
+	}
+
+	for k, v := range l.Languages {
+		// First language wins if keywords repeat (same as linear search).
+		if _, exist := index.keys[v.Keyword]; !exist {
+			index.keys[v.Keyword] = k
+		}
+
+		normalized := normalizeKeyword(v.Keyword)
+		if _, exist := index.keys[normalized]; !exist {
+			index.keys[normalized] = k
+		}
+	}
+
+	for k := range l.Languages {
+		language := &l.Languages[k]
+
+		index.chains[k] = l.buildFallbackChain(&index, language)
+		index.strict[k] = l.appendParentLanguages(&index, []*Language{language}, language)
+	}
+
+	return &index
+}
+
+// newLazyLocaleIndex builds localeIndex for Locale without stored (or with
+// outdated) index. Lazy index has no lookup table and chains, keywords are
+// searched linearly and only requested chain is built, so one-off lookups do
+// not pay for the whole index.
+func newLazyLocaleIndex(l *Locale) *localeIndex {
+	return &localeIndex{languages: l.Languages, locale: l}
+}
+
+// isValid checks if index still represents passed languages slice.
+// Returns false if slice was replaced, re-allocated or resized. Keywords
+// changed in place are not detected (see Locale).
+func (i *localeIndex) isValid(languages []Language) bool {
+	if len(i.languages) != len(languages) {
+		return false
+	}
+
+	if len(languages) == 0 {
+		return true
+	}
+
+	return &i.languages[0] == &languages[0]
+}
+
+// find returns Locale.Languages index of language with passed keyword.
+// Exact keyword match does not allocate, other cases are normalized first.
+func (i *localeIndex) find(langKey string) (int, bool) {
+	if i.keys == nil {
+		return i.search(langKey)
+	}
+
+	idx, exist := i.keys[langKey]
+	if exist {
+		return idx, true
+	}
+
+	idx, exist = i.keys[normalizeKeyword(langKey)]
+
+	return idx, exist
+}
+
+// search returns Locale.Languages index of language with passed keyword using
+// linear search (lazy index). Exact keyword matches are checked first.
+func (i *localeIndex) search(langKey string) (int, bool) {
+	for k, v := range i.languages {
+		if v.Keyword == langKey {
+			return k, true
+		}
+	}
+
+	normalized := normalizeKeyword(langKey)
+
+	for k, v := range i.languages {
+		if normalizeKeyword(v.Keyword) == normalized {
+			return k, true
+		}
+	}
+
+	return 0, false
+}
+
+// chain returns fallback chain of language with passed Locale.Languages index.
+// Only language and its parent languages are returned if strictUsage is true.
+func (i *localeIndex) chain(idx int, strictUsage bool) []*Language {
+	if i.locale != nil {
+		language := &i.languages[idx]
+		if strictUsage {
+			return i.locale.appendParentLanguages(i, []*Language{language}, language)
+		}
+
+		return i.locale.buildFallbackChain(i, language)
+	}
+
+	if strictUsage {
+		return i.strict[idx]
+	}
+
+	return i.chains[idx]
+}
+
+// resolve returns Locale.Languages index of language with passed keyword.
+// If language does not exist, then its parent languages are searched, for
+// example, "en-US" -> "en".
+func (i *localeIndex) resolve(langKey string) (int, bool) {
+	idx, exist := i.find(langKey)
+	if exist {
+		return idx, true
+	}
+
+	return i.findParent(langKey)
+}
+
+// findParent returns Locale.Languages index of the closest initialized parent
+// language of passed keyword ("en-US" -> "en").
+func (i *localeIndex) findParent(langKey string) (int, bool) {
+	tag, err := ParseTag(langKey)
+	if err != nil {
+		return 0, false
+	}
+
+	for parent, ok := tag.Parent(); ok; parent, ok = parent.Parent() {
+		idx, exist := i.find(parent.String())
+		if exist {
+			return idx, true
+		}
+	}
+
+	return 0, false
+}
+
+// normalizeKeyword returns language keyword in form used for index keys.
+// Valid BCP 47 tags are canonicalized, other keywords are lower-cased.
+func normalizeKeyword(langKey string) string {
+	keyword, err := CanonicalTag(langKey)
+	if err != nil {
+		return strings.ToLower(langKey)
+	}
+
+	return keyword
+}
@@ -0,0 +1,286 @@
+package localization
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Localizer is language-bound handle for Locale translations, so language
+// keyword does not have to be passed on every call. Language gets resolved
+// once on creation (see Locale.For) and Localizer is cheap to create for
+// every request.
+// Text methods (T, Tf, TN, TNamed, TNNamed) return missing key text for
+// missing keys as configured by Locale.MissingBehavior (empty string for
+// MissingAsError, use Value or ValuePlural to get error).
+// Zero value Localizer returns empty strings.
+type Localizer struct {
+	locale   *Locale
+	language string
+}
+
+// For can be used to create Localizer bound to the first matching language.
+// Each passed value can be language keyword ("lv", "en-US") or request header
+// Accept-Language value ("en-US,en;q=0.5"). Regional variants resolve to
+// parent language ("en-US" -> "en").
+// If none of values match, then default language (see Locale.SetDefaultLanguage)
+// or the first initialized language is used.
+//
+// Params:
+// langOrAcceptHeader - language keywords or Accept-Language values in priority order.
+func (l *Locale) For(langOrAcceptHeader ...string) Localizer {
+	return Localizer{locale: l, language: l.negotiate(langOrAcceptHeader...)}
+}
+
+// negotiate returns keyword of the first initialized language matching passed
+// language keywords or Accept-Language values, or fallback language if none
+// of values match.
+func (l *Locale) negotiate(langOrAcceptHeader ...string) string {
+	index := l.getIndex()
+
+	for _, v := range langOrAcceptHeader {
+		if v == "" {
+			continue
+		}
+
+		idx, exist := index.resolve(v)
+		if exist {
+			return l.Languages[idx].Keyword
+		}
+
+		lang, exist := l.matchAcceptLanguage(v)
+		if exist {
+			return lang
+		}
+	}
+
+	return l.fallbackLanguage()
+}
+
+// matchAcceptLanguage returns keyword of the first initialized language
+// matching passed Accept-Language value.
+// Returns false if none of languages match.
+func (l *Locale) matchAcceptLanguage(header string) (string, bool) {
+	lang := ParseAcceptLanguage(header).FindFirstMatchingLang(l.EnabledLanguages(), l.fallbackLanguage())
+	return lang, lang != ""
+}
+
+// fallbackLanguage returns default language keyword or the first initialized
+// language keyword if default language is not set.
+// Returns empty string if there are no initialized languages.
+func (l *Locale) fallbackLanguage() string {
+	if l.defaultLang != "" {
+		return l.defaultLang
+	}
+
+	if len(l.Languages) == 0 {
+		return ""
+	}
+
+	return l.Languages[0].Keyword
+}
+
+// Language returns keyword of language Localizer is bound to.
+func (lz Localizer) Language() string {
+	return lz.language
+}
+
+// Locale returns Locale Localizer is bound to.
+func (lz Localizer) Locale() *Locale {
+	return lz.locale
+}
+
+// Value returns non-plural translation (see Locale.Value).
+// Returns error if key does not exist.
+func (lz Localizer) Value(key string) (string, error) {
+	if lz.locale == nil {
+		return "", &LanguageError{Err: ErrLanguageNotFound, Language: lz.language}
+	}
+
+	return lz.locale.Value(lz.language, key)
+}
+
+// ValuePlural returns plural translation (see Locale.ValuePlural).
+// Returns error if key does not exist.
+func (lz Localizer) ValuePlural(key string) (string, error) {
+	if lz.locale == nil {
+		return "", &LanguageError{Err: ErrLanguageNotFound, Language: lz.language}
+	}
+
+	return lz.locale.ValuePlural(lz.language, key)
+}
+
+// Lookup returns non-plural translation with information about language it came
+// from (see Locale.Lookup).
+// Returns error if key does not exist.
+func (lz Localizer) Lookup(key string) (LookupResult, error) {
+	if lz.locale == nil {
+		return LookupResult{}, &LanguageError{Err: ErrLanguageNotFound, Language: lz.language}
+	}
+
+	return lz.locale.Lookup(lz.language, key)
+}
+
+// T returns non-plural translation or missing key text (see
+// Locale.MissingBehavior, empty string for MissingAsError) if key does not
+// exist.
+func (lz Localizer) T(key string) string {
+	text, _ := lz.t(key)
+	return text
+}
+
+// Tf returns non-plural translation formatted with passed args (see Textf),
+// or missing key text if key does not exist.
+func (lz Localizer) Tf(key string, args ...interface{}) string {
+	text, _ := lz.tf(key, args...)
+	return text
+}
+
+// TN returns plural translation if count is greater than 1 or non-plural
+// translation otherwise, formatted with count followed by passed args (see
+// TextPluralIntf), or missing key text if key does not exist.
+// For example:
+// key_item: ["%d item %s", "%d items %s"]
+// TN("key_item", 2, ":)") -> "2 items :)"
+func (lz Localizer) TN(key string, count int, args ...interface{}) string {
+	text, _ := lz.tn(key, count, args...)
+	return text
+}
+
+// TNamed returns non-plural translation with named placeholders replaced by
+// passed args, or missing key text if key does not exist.
+// Placeholders are written as {name}, unknown placeholders are left as is.
+// For example:
+// key_hello: "Hello, {name}!"
+// TNamed("key_hello", map[string]interface{}{"name": "John"}) -> "Hello, John!"
+func (lz Localizer) TNamed(key string, args map[string]interface{}) string {
+	text, _ := lz.tnamed(key, args)
+	return text
+}
+
+// TNNamed returns plural translation if count is greater than 1 or non-plural
+// translation otherwise, with named placeholders replaced by passed args, or
+// missing key text if key does not exist. Placeholder {count} is replaced by count
+// unless args contain "count".
+// For example:
+// key_item: ["{count} item in {place}", "{count} items in {place}"]
+// TNNamed("key_item", 2, map[string]interface{}{"place": "cart"}) -> "2 items in cart"
+func (lz Localizer) TNNamed(key string, count int, args map[string]interface{}) string {
+	text, err := lz.pluralValue(key, count)
+	if err != nil {
+		return lz.missing(key)
+	}
+
+	if _, exist := args["count"]; !exist {
+		withCount := make(map[string]interface{}, len(args)+1)
+		for k, v := range args {
+			withCount[k] = v
+		}
+
+		withCount["count"] = count
+		args = withCount
+	}
+
+	return formatNamed(text, args)
+}
+
+// t returns non-plural translation or missing key text and lookup error.
+func (lz Localizer) t(key string) (string, error) {
+	text, err := lz.Value(key)
+	if err != nil {
+		return lz.missing(key), err
+	}
+
+	return text, nil
+}
+
+// tf returns formatted non-plural translation or missing key text and lookup
+// error.
+func (lz Localizer) tf(key string, args ...interface{}) (string, error) {
+	text, err := lz.Value(key)
+	if err != nil {
+		return lz.missing(key), err
+	}
+
+	return fmt.Sprintf(text, args...), nil
+}
+
+// tn returns plural or non-plural translation formatted with count and args,
+// or missing key text and lookup error.
+func (lz Localizer) tn(key string, count int, args ...interface{}) (string, error) {
+	text, err := lz.pluralValue(key, count)
+	if err != nil {
+		return lz.missing(key), err
+	}
+
+	return fmt.Sprintf(text, append([]interface{}{count}, args...)...), nil
+}
+
+// tnamed returns non-plural translation with named placeholders replaced, or
+// missing key text and lookup error.
+func (lz Localizer) tnamed(key string, args map[string]interface{}) (string, error) {
+	text, err := lz.Value(key)
+	if err != nil {
+		return lz.missing(key), err
+	}
+
+	return formatNamed(text, args), nil
+}
+
+// missing returns text for missing key (see Locale.MissingBehavior).
+func (lz Localizer) missing(key string) string {
+	if lz.locale == nil {
+		return ""
+	}
+
+	return lz.locale.MissingBehavior.text(key)
+}
+
+// pluralValue returns plural translation if count is greater than 1 or
+// non-plural translation otherwise.
+func (lz Localizer) pluralValue(key string, count int) (string, error) {
+	if count > 1 {
+		return lz.ValuePlural(key)
+	}
+
+	return lz.Value(key)
+}
+
+// formatNamed replaces {name} placeholders in text with passed args.
+// Placeholders without matching arg are left as is.
+func formatNamed(text string, args map[string]interface{}) string {
+	if len(args) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+
+	builder := strings.Builder{}
+	builder.Grow(len(text))
+
+	for {
+		start := strings.IndexByte(text, '{')
+		if start < 0 {
+			break
+		}
+
+		end := strings.IndexByte(text[start:], '}')
+		if end < 0 {
+			break
+		}
+
+		end += start
+		value, exist := args[text[start+1:end]]
+
+		builder.WriteString(text[:start])
+
+		if exist {
+			builder.WriteString(fmt.Sprint(value))
+		} else {
+			builder.WriteString(text[start : end+1])
+		}
+
+		text = text[end+1:]
+	}
+
+	builder.WriteString(text)
+
+	return builder.String()
+}
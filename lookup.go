@@ -0,0 +1,83 @@
+package localization
+
+// Source holds information about where translation was defined.
+// Empty Source means that translation was added manually (not from file).
+type Source struct {
+	File string // YAML file path.
+	Line int    // Line in YAML file (0 if unknown).
+}
+
+// LookupResult holds translation lookup result with information about
+// language translation actually came from.
+type LookupResult struct {
+	Text      string // Resolved translation.
+	Key       string // Translation keyword/key.
+	Requested string // Requested language keyword.
+	Language  string // Language keyword translation came from (use for "lang" attributes).
+	Fallback  bool   // Is translation borrowed from other language than requested.
+	Plural    bool   // Is translation plural value.
+	Source    Source // Translation source file and line.
+}
+
+// sourceKey is key of Locale.sources map.
+type sourceKey struct {
+	lang string // Canonical language keyword.
+	key  string // Translation keyword/key.
+}
+
+// Lookup can be used to extract non-plural translation from target language
+// with information about language translation came from, and whether
+// fallback was used. Follows the same rules as Locale.Value.
+// Returns LookupResult or error if langKey does not exist, or key does not exist.
+//
+// Params:
+// langKey - target language keyword ("en", "lv" etc).
+// textKey - translation keyword/key.
+func (l *Locale) Lookup(langKey, textKey string) (LookupResult, error) {
+	return l._lookup(langKey, textKey, false)
+}
+
+// LookupPlural can be used to extract plural translation from target language
+// with information about language translation came from, and whether
+// fallback was used. Follows the same rules as Locale.ValuePlural.
+// Returns LookupResult or error if langKey does not exist, or key does not exist.
+//
+// Params:
+// langKey - target language keyword ("en", "lv" etc).
+// textKey - translation keyword/key.
+func (l *Locale) LookupPlural(langKey, textKey string) (LookupResult, error) {
+	return l._lookup(langKey, textKey, true)
+}
+
+// _lookup is helper method for Locale.Lookup and Locale.LookupPlural.
+func (l *Locale) _lookup(langKey, textKey string, isPlural bool) (LookupResult, error) {
+	text, language, fallback, err := l.search(langKey, textKey, isPlural)
+	if err != nil {
+		return LookupResult{}, err
+	}
+
+	return LookupResult{
+		Text:      text,
+		Key:       textKey,
+		Requested: langKey,
+		Language:  language.Keyword,
+		Fallback:  fallback,
+		Plural:    isPlural,
+		Source:    l.sources[sourceKey{language.Keyword, textKey}],
+	}, nil
+}
+
+// setSource stores translation source for language keyword and key.
+// Empty source removes stored source.
+func (l *Locale) setSource(langKey, textKey string, source Source) {
+	if source == (Source{}) {
+		delete(l.sources, sourceKey{langKey, textKey})
+		return
+	}
+
+	if l.sources == nil {
+		l.sources = make(map[sourceKey]Source)
+	}
+
+	l.sources[sourceKey{langKey, textKey}] = source
+}
@@ -0,0 +1,207 @@
+package localization
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LanguageSource defines where request language can be taken from (see
+// Middleware.Sources).
+type LanguageSource int
+
+const (
+	SourcePath    LanguageSource = iota // The first URL path segment ("/lv/about").
+	SourceQuery                         // URL query parameter ("?lang=lv").
+	SourceCookie                        // Cookie value.
+	SourceHeader                        // Request header Accept-Language.
+	SourceDefault                       // Default language (see Locale.SetDefaultLanguage).
+)
+
+// String returns language source name.
+func (s LanguageSource) String() string {
+	switch s {
+	case SourcePath:
+		return "path"
+	case SourceQuery:
+		return "query"
+	case SourceCookie:
+		return "cookie"
+	case SourceHeader:
+		return "header"
+	case SourceDefault:
+		return "default"
+	default:
+		return "unknown"
+	}
+}
+
+// Middleware is net/http middleware which resolves request language from
+// ordered list of sources, stores Localizer in request context (see
+// FromContext) and sets response headers Content-Language and
+// Vary: Accept-Language.
+// Use NewMiddleware to construct Middleware with default settings.
+type Middleware struct {
+	Locale       *Locale          // Locale with initialized languages.
+	Sources      []LanguageSource // Language sources in priority order, default language is used if none match.
+	QueryParam   string           // URL query parameter name for SourceQuery.
+	CookieName   string           // Cookie name for SourceCookie and Persist.
+	Persist      bool             // Store language chosen by path or query in cookie (CookieName).
+	CookieMaxAge time.Duration    // Persisted cookie lifetime.
+}
+
+// NewMiddleware can be used to construct Middleware with default settings:
+// sources path prefix, query parameter "lang", cookie "lang", Accept-Language
+// and then default language. Language is not persisted in cookie.
+//
+// Params:
+// locale - Locale with initialized languages.
+func NewMiddleware(locale *Locale) *Middleware {
+	return &Middleware{
+		Locale:       locale,
+		Sources:      []LanguageSource{SourcePath, SourceQuery, SourceCookie, SourceHeader},
+		QueryParam:   "lang",
+		CookieName:   "lang",
+		CookieMaxAge: 365 * 24 * time.Hour,
+	}
+}
+
+// Handler wraps passed handler with language negotiation.
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang, source := m.resolve(r)
+
+		header := w.Header()
+		header.Set("Content-Language", lang)
+
+		if !containsHeaderValue(header.Values("Vary"), "Accept-Language") {
+			header.Add("Vary", "Accept-Language")
+		}
+
+		if m.Persist && (source == SourcePath || source == SourceQuery) {
+			m.persist(w, r, lang)
+		}
+
+		ctx := WithLocalizer(r.Context(), Localizer{locale: m.Locale, language: lang})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Negotiate returns Localizer bound to request language resolved from
+// Middleware sources. Can be used without Handler.
+func (m *Middleware) Negotiate(r *http.Request) Localizer {
+	lang, _ := m.resolve(r)
+	return Localizer{locale: m.Locale, language: lang}
+}
+
+// resolve returns request language keyword and source it came from.
+func (m *Middleware) resolve(r *http.Request) (string, LanguageSource) {
+	for _, source := range m.Sources {
+		lang, exist := m.fromSource(r, source)
+		if exist {
+			return lang, source
+		}
+	}
+
+	return m.Locale.fallbackLanguage(), SourceDefault
+}
+
+// fromSource returns language keyword from passed source.
+// Returns false if source does not contain initialized language.
+func (m *Middleware) fromSource(r *http.Request, source LanguageSource) (string, bool) {
+	switch source {
+	case SourcePath:
+		return m.fromValue(pathPrefix(r.URL.Path))
+	case SourceQuery:
+		if m.QueryParam == "" {
+			return "", false
+		}
+
+		return m.fromValue(r.URL.Query().Get(m.QueryParam))
+	case SourceCookie:
+		if m.CookieName == "" {
+			return "", false
+		}
+
+		cookie, err := r.Cookie(m.CookieName)
+		if err != nil {
+			return "", false
+		}
+
+		return m.fromValue(cookie.Value)
+	case SourceHeader:
+		value := r.Header.Get("Accept-Language")
+		if value == "" {
+			return "", false
+		}
+
+		return m.Locale.matchAcceptLanguage(value)
+	case SourceDefault:
+		lang := m.Locale.fallbackLanguage()
+		return lang, lang != ""
+	}
+
+	return "", false
+}
+
+// fromValue returns keyword of initialized language matching passed value
+// (or its parent language).
+// Returns false if language is not initialized.
+func (m *Middleware) fromValue(value string) (string, bool) {
+	if value == "" {
+		return "", false
+	}
+
+	idx, exist := m.Locale.getIndex().resolve(value)
+	if !exist {
+		return "", false
+	}
+
+	return m.Locale.Languages[idx].Keyword, true
+}
+
+// persist stores language in cookie.
+func (m *Middleware) persist(w http.ResponseWriter, r *http.Request, lang string) {
+	if m.CookieName == "" {
+		return
+	}
+
+	cookie, err := r.Cookie(m.CookieName)
+	if err == nil && cookie.Value == lang {
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     m.CookieName,
+		Value:    lang,
+		Path:     "/",
+		MaxAge:   int(m.CookieMaxAge.Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// pathPrefix returns the first URL path segment ("/lv/about" -> "lv").
+func pathPrefix(path string) string {
+	path = strings.TrimPrefix(path, "/")
+
+	end := strings.IndexByte(path, '/')
+	if end < 0 {
+		return path
+	}
+
+	return path[:end]
+}
+
+// containsHeaderValue checks if comma separated header values contain value.
+func containsHeaderValue(values []string, value string) bool {
+	for _, v := range values {
+		for _, y := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(y), value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
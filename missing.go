@@ -0,0 +1,293 @@
+package localization
+
+import (
+	"bytes"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// maxMissingKeyCallers limits count of unique callers stored for each
+// MissingKeyEntry.
+const maxMissingKeyCallers = 10
+
+// packageDir is directory of this package source files, used to find first
+// caller outside of this package (see missingKeyCaller).
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// MissingKey holds information about translation key, which could not be
+// found by Locale lookup.
+type MissingKey struct {
+	Language string // Requested language keyword.
+	Key      string // Translation keyword/key.
+	Plural   bool   // Was plural value requested.
+	Caller   string // Caller location outside of this package ("file.go:42"), empty if unknown.
+}
+
+// MissingKeyHandler is function which gets called every time when Locale
+// can not find translation key (see Locale.OnMissingKey).
+// Handler can be called concurrently and must not call Locale lookup methods
+// with missing keys (infinite recursion).
+type MissingKeyHandler func(missing MissingKey)
+
+// MissingBehavior defines what Localizer methods and template functions
+// (see Localizer.FuncMap) return when translation key can not be found.
+type MissingBehavior int
+
+const (
+	MissingAsEmpty       MissingBehavior = iota // Empty string (default).
+	MissingAsKey                                // Translation key ("page.title").
+	MissingAsPlaceholder                        // Translation key in brackets ("[page.title]").
+	MissingAsError                              // Error, template execution fails (Localizer methods return empty string).
+)
+
+// text returns text for missing key.
+func (b MissingBehavior) text(key string) string {
+	switch b {
+	case MissingAsKey:
+		return key
+	case MissingAsPlaceholder:
+		return "[" + key + "]"
+	default:
+		return ""
+	}
+}
+
+// MissingKeyEntry holds deduplicated information about missing translation key
+// collected by MissingKeyCollector.
+type MissingKeyEntry struct {
+	Language string   // Canonical language keyword.
+	Key      string   // Translation keyword/key.
+	Plural   bool     // Was plural value requested at least once.
+	Count    int      // How many times key was requested.
+	Callers  []string // Unique caller locations (limited to 10).
+}
+
+// MissingKeyCollector can be used to collect and deduplicate missing translation
+// keys. Use MissingKeyCollector.Handle as Locale.OnMissingKey handler.
+// Collected keys can be exported as report or as YAML stubs for translators.
+// Safe for concurrent use.
+type MissingKeyCollector struct {
+	mutex   sync.Mutex
+	entries map[sourceKey]*MissingKeyEntry
+}
+
+// NewMissingKeyCollector constructs new, empty MissingKeyCollector.
+func NewMissingKeyCollector() *MissingKeyCollector {
+	return &MissingKeyCollector{entries: make(map[sourceKey]*MissingKeyEntry)}
+}
+
+// Handle adds missing key to the collector. Can be used as Locale.OnMissingKey
+// handler:
+//
+//	locale.OnMissingKey = collector.Handle
+func (c *MissingKeyCollector) Handle(missing MissingKey) {
+	key := sourceKey{normalizeKeyword(missing.Language), missing.Key}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[sourceKey]*MissingKeyEntry)
+	}
+
+	entry, exist := c.entries[key]
+	if !exist {
+		entry = &MissingKeyEntry{Language: key.lang, Key: key.key}
+		c.entries[key] = entry
+	}
+
+	entry.Count++
+	entry.Plural = entry.Plural || missing.Plural
+
+	if missing.Caller == "" || len(entry.Callers) >= maxMissingKeyCallers {
+		return
+	}
+
+	for _, v := range entry.Callers {
+		if v == missing.Caller {
+			return
+		}
+	}
+
+	entry.Callers = append(entry.Callers, missing.Caller)
+}
+
+// Entries returns collected missing keys sorted by language and key.
+func (c *MissingKeyCollector) Entries() []MissingKeyEntry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entries := make([]MissingKeyEntry, 0, len(c.entries))
+
+	for _, v := range c.entries {
+		entry := *v
+		entry.Callers = append([]string(nil), v.Callers...)
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Language != entries[j].Language {
+			return entries[i].Language < entries[j].Language
+		}
+
+		return entries[i].Key < entries[j].Key
+	})
+
+	return entries
+}
+
+// Reset removes all collected missing keys.
+func (c *MissingKeyCollector) Reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = make(map[sourceKey]*MissingKeyEntry)
+}
+
+// WriteReport writes human-readable report of collected missing keys, one
+// key per line, for example:
+//
+//	lv	key0	3	plural	main.go:12, main.go:20
+//
+// Returns error if write fails.
+func (c *MissingKeyCollector) WriteReport(w io.Writer) error {
+	for _, v := range c.Entries() {
+		kind := "non-plural"
+		if v.Plural {
+			kind = "plural"
+		}
+
+		_, err := fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n",
+			v.Language, v.Key, v.Count, kind, strings.Join(v.Callers, ", "))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteYAML writes collected missing keys as YAML translate file with empty
+// values, which can be filled in by translators and loaded with
+// Locale.LoadYAMLFile. Keys requested as plural get both non-plural and plural
+// entries.
+// Returns error if something went wrong.
+func (c *MissingKeyCollector) WriteYAML(w io.Writer) error {
+	entries := c.Entries()
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	content := yaml.Node{Kind: yaml.MappingNode}
+
+	for k := 0; k < len(entries); {
+		key := entries[k].Key
+		languages := &yaml.Node{Kind: yaml.SequenceNode}
+
+		for ; k < len(entries) && entries[k].Key == key; k++ {
+			value := yamlStringNode("")
+			if entries[k].Plural {
+				value = &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{yamlStringNode(""), yamlStringNode("")}}
+			}
+
+			languages.Content = append(languages.Content, &yaml.Node{Kind: yaml.MappingNode,
+				Content: []*yaml.Node{yamlStringNode(entries[k].Language), value}})
+		}
+
+		content.Content = append(content.Content, yamlStringNode(key), languages)
+	}
+
+	if len(content.Content) == 0 {
+		return nil
+	}
+
+	buffer := bytes.Buffer{}
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	err := encoder.Encode(&content)
+	if err == nil {
+		err = encoder.Close()
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal missing keys: %w", err)
+	}
+
+	_, err = w.Write(buffer.Bytes())
+
+	return err
+}
+
+// yamlStringNode returns string scalar node, quoted if required ("no").
+func yamlStringNode(value string) *yaml.Node {
+	node := &yaml.Node{}
+
+	// Encoding string can not fail.
+	_ = node.Encode(value)
+
+	return node
+}
+
+// reportMissingKey calls Locale.OnMissingKey handler (if set) with information
+// about missing key and caller location.
+func (l *Locale) reportMissingKey(langKey, textKey string, isPlural bool) {
+	if l.OnMissingKey == nil {
+		return
+	}
+
+	l.OnMissingKey(MissingKey{
+		Language: langKey,
+		Key:      textKey,
+		Plural:   isPlural,
+		Caller:   missingKeyCaller(),
+	})
+}
+
+// missingKeyCaller returns location of the first caller outside of this
+// package and template execution (text/template, html/template and reflect
+// frames, so template function calls report Template.Execute caller), for
+// example, "file.go:42", or empty string if it can not be determined.
+func missingKeyCaller() string {
+	pc := make([]uintptr, 64)
+	count := runtime.Callers(2, pc)
+	frames := runtime.CallersFrames(pc[:count])
+
+	for {
+		frame, more := frames.Next()
+
+		isPackageFile := filepath.Dir(frame.File) == packageDir &&
+			!strings.HasSuffix(frame.File, "_test.go")
+
+		if frame.File != "" && !isPackageFile && !isTemplateFrame(frame.Function) {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
+
+// isTemplateFrame checks if function belongs to template execution.
+func isTemplateFrame(function string) bool {
+	for _, v := range []string{"text/template.", "html/template.", "reflect."} {
+		if strings.HasPrefix(function, v) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,315 @@
+package localization
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// Segment is part of rich text translation: either plain text or named tag
+// with nested segments, for example, "Read <link>terms</link>" ->
+// [{Text: "Read "}, {Tag: "link", Children: [{Text: "terms"}]}].
+// Use ParseRichText to parse translation into segments.
+type Segment struct {
+	Tag      string    // Tag name, empty for text segment.
+	Text     string    // Text, only for text segment.
+	Children []Segment // Tag content, only for tag segment.
+}
+
+// RichTag renders tag content, which is already rendered by RichRenderer
+// (escaped in HTML), for example, wraps it in HTML element.
+type RichTag func(content string) string
+
+// RichRenderer renders rich text segments. Tag names are mapped to callbacks,
+// so markup (link URLs, styles) comes from code, not from translators.
+// Content of tags without callback is rendered without tag.
+type RichRenderer struct {
+	Text func(text string) string // Text segment renderer, for example, escaping (text as is if nil).
+	Tags map[string]RichTag       // Tag renderers by tag name.
+}
+
+// ParseRichText can be used to split translation value into segments with
+// named tags: "<name>content</name>" or "<name/>". Tag names must start with
+// letter and contain only letters, digits, "-" and "_". Tags can be nested.
+// "<" not starting valid tag is treated as text ("a < b", "<3").
+// Returns segments or error (ErrRichTextSyntax) if tags are unclosed or
+// mismatched.
+func ParseRichText(value string) ([]Segment, error) {
+	type frame struct {
+		tag      string
+		segments []Segment
+	}
+
+	stack := []frame{{}}
+	text := strings.Builder{}
+
+	flush := func() {
+		if text.Len() == 0 {
+			return
+		}
+
+		top := &stack[len(stack)-1]
+		top.segments = append(top.segments, Segment{Text: text.String()})
+		text.Reset()
+	}
+
+	for pos := 0; pos < len(value); {
+		name, closing, selfClosing, size := parseRichTag(value[pos:])
+		if size == 0 {
+			text.WriteByte(value[pos])
+			pos++
+
+			continue
+		}
+
+		flush()
+
+		switch {
+		case selfClosing:
+			top := &stack[len(stack)-1]
+			top.segments = append(top.segments, Segment{Tag: name})
+		case closing:
+			top := stack[len(stack)-1]
+			if len(stack) == 1 || top.tag != name {
+				return nil, fmt.Errorf("%w: unexpected closing tag '%s' at offset %d", ErrRichTextSyntax, name, pos)
+			}
+
+			stack = stack[:len(stack)-1]
+			parent := &stack[len(stack)-1]
+			parent.segments = append(parent.segments, Segment{Tag: name, Children: top.segments})
+		default:
+			stack = append(stack, frame{tag: name})
+		}
+
+		pos += size
+	}
+
+	flush()
+
+	if len(stack) > 1 {
+		return nil, fmt.Errorf("%w: unclosed tag '%s'", ErrRichTextSyntax, stack[len(stack)-1].tag)
+	}
+
+	return stack[0].segments, nil
+}
+
+// Render renders segments.
+func (r RichRenderer) Render(segments []Segment) string {
+	builder := strings.Builder{}
+	r.render(&builder, segments)
+
+	return builder.String()
+}
+
+// render writes rendered segments into builder.
+func (r RichRenderer) render(builder *strings.Builder, segments []Segment) {
+	for _, v := range segments {
+		if v.Tag == "" {
+			if r.Text == nil {
+				builder.WriteString(v.Text)
+			} else {
+				builder.WriteString(r.Text(v.Text))
+			}
+
+			continue
+		}
+
+		content := r.Render(v.Children)
+
+		tag, exist := r.Tags[v.Tag]
+		if !exist {
+			builder.WriteString(content)
+			continue
+		}
+
+		builder.WriteString(tag(content))
+	}
+}
+
+// PlainText returns text of segments without tags.
+func PlainText(segments []Segment) string {
+	return RichRenderer{}.Render(segments)
+}
+
+// HTMLRenderer returns RichRenderer which HTML escapes text segments.
+// Use HTMLElement to create tag renderers.
+func HTMLRenderer(tags map[string]RichTag) RichRenderer {
+	return RichRenderer{Text: template.HTMLEscapeString, Tags: tags}
+}
+
+// HTMLElement can be used to create tag renderer which wraps content in HTML
+// element with passed attributes (name and value pairs, values are escaped),
+// for example, HTMLElement("a", "href", "/terms") -> <a href="/terms">content</a>.
+// URL attributes (href, src, ...) must be relative or use scheme allowed by
+// DefaultHTMLPolicy.
+// Returns tag renderer or error (ErrHTMLElement) if element or attribute name
+// is invalid, element is script-like, attribute is event handler (on*), URL is
+// not allowed or attributes are not pairs.
+func HTMLElement(name string, attributes ...string) (RichTag, error) {
+	return DefaultHTMLPolicy().element(name, attributes...)
+}
+
+// ANSI terminal styles for ANSIRenderer.
+var (
+	ANSIBold      RichTag = ansiStyle("\x1b[1m", "\x1b[22m")
+	ANSIDim       RichTag = ansiStyle("\x1b[2m", "\x1b[22m")
+	ANSIItalic    RichTag = ansiStyle("\x1b[3m", "\x1b[23m")
+	ANSIUnderline RichTag = ansiStyle("\x1b[4m", "\x1b[24m")
+)
+
+// ANSIRenderer returns RichRenderer for terminal output. Tags "b", "i", "u"
+// and "dim" are rendered with matching ANSI styles unless passed tags
+// override them. Escape characters are removed from text segments.
+func ANSIRenderer(tags map[string]RichTag) RichRenderer {
+	all := map[string]RichTag{
+		"b":   ANSIBold,
+		"i":   ANSIItalic,
+		"u":   ANSIUnderline,
+		"dim": ANSIDim,
+	}
+
+	for k, v := range tags {
+		all[k] = v
+	}
+
+	return RichRenderer{
+		Text: func(text string) string { return strings.ReplaceAll(text, "\x1b", "") },
+		Tags: all,
+	}
+}
+
+// ANSILink returns tag renderer which renders content as terminal hyperlink
+// (OSC 8) to passed URL.
+func ANSILink(url string) RichTag {
+	url = strings.ReplaceAll(url, "\x1b", "")
+
+	return func(content string) string {
+		return "\x1b]8;;" + url + "\x1b\\" + content + "\x1b]8;;\x1b\\"
+	}
+}
+
+// Rich returns non-plural translation parsed into segments (see
+// ParseRichText).
+// Returns error if key does not exist or translation is not valid rich text.
+func (lz Localizer) Rich(key string) ([]Segment, error) {
+	text, err := lz.Value(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRichText(text)
+}
+
+// RichHTML returns non-plural translation rendered as HTML with passed tag
+// renderers (see HTMLElement). Named placeholders ({name}) in text segments
+// are replaced with escaped args.
+// Returns escaped missing key text (see Locale.MissingBehavior) if key does
+// not exist, or escaped plain translation if it is not valid rich text.
+func (lz Localizer) RichHTML(key string, tags map[string]RichTag, args map[string]interface{}) template.HTML {
+	text, _ := lz.richHTML(key, tags, args)
+	return text
+}
+
+// richHTML returns rendered rich text translation and lookup or rich text
+// syntax error.
+func (lz Localizer) richHTML(key string, tags map[string]RichTag, args map[string]interface{}) (template.HTML, error) {
+	text, err := lz.Value(key)
+	if err != nil {
+		return template.HTML(template.HTMLEscapeString(lz.missing(key))), err
+	}
+
+	segments, err := ParseRichText(text)
+	if err != nil {
+		return template.HTML(template.HTMLEscapeString(formatNamed(text, args))), err
+	}
+
+	renderer := HTMLRenderer(tags)
+	renderer.Text = func(text string) string {
+		return template.HTMLEscapeString(formatNamed(text, args))
+	}
+
+	return template.HTML(renderer.Render(segments)), nil
+}
+
+// parseRichTag parses tag at the beginning of value.
+// Returns tag name, is it closing or self-closing tag and tag size or 0 if
+// value does not start with valid tag.
+func parseRichTag(value string) (string, bool, bool, int) {
+	if len(value) < 3 || value[0] != '<' {
+		return "", false, false, 0
+	}
+
+	end := strings.IndexByte(value, '>')
+	if end < 0 {
+		return "", false, false, 0
+	}
+
+	name := value[1:end]
+	closing := strings.HasPrefix(name, "/")
+	selfClosing := strings.HasSuffix(name, "/")
+
+	if closing && selfClosing {
+		return "", false, false, 0
+	}
+
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "/"), "/")
+	if !isRichTagName(name) {
+		return "", false, false, 0
+	}
+
+	return name, closing, selfClosing, end + 1
+}
+
+// isHTMLName checks if name is valid HTML element or attribute name: starts
+// with letter and contains only letters, digits, "-", "_" (attributes only)
+// and ":" (attributes only, for example, "xlink:href").
+func isHTMLName(name string, attribute bool) bool {
+	if name == "" || !isAlpha(name[:1]) {
+		return false
+	}
+
+	for k := 1; k < len(name); k++ {
+		c := name[k : k+1]
+		if isAlphaNum(c) || c == "-" || (attribute && (c == "_" || c == ":")) {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// isURLAttribute checks if attribute value is URL.
+func isURLAttribute(name string) bool {
+	switch name {
+	case "href", "src", "action", "formaction", "cite", "poster", "background", "xlink:href":
+		return true
+	}
+
+	return false
+}
+
+// isRichTagName checks if name is valid rich text tag name.
+func isRichTagName(name string) bool {
+	if name == "" || !isAlpha(name[:1]) {
+		return false
+	}
+
+	for k := 1; k < len(name); k++ {
+		c := name[k : k+1]
+		if !isAlphaNum(c) && c != "-" && c != "_" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ansiStyle returns tag renderer which wraps content in ANSI start and reset
+// sequences.
+func ansiStyle(start, reset string) RichTag {
+	return func(content string) string {
+		return start + content + reset
+	}
+}
@@ -0,0 +1,260 @@
+package localization
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// routePathContextKey is context key for request path without language
+// prefix and with canonical (not translated) segments.
+type routePathContextKey struct{}
+
+// Router is net/http helper for localized URLs with language path prefixes
+// ("/lv/...", "/en/..."), built on Locale.EnabledLanguages. Router strips and
+// validates language prefix, stores Localizer in request context (see
+// FromContext), redirects paths without language prefix to negotiated
+// language and generates localized URLs.
+//
+// Path segments can be translated with translation keys starting with
+// SegmentPrefix, for example, with SegmentPrefix "route.":
+//
+//	route.contact:
+//	  - en: "contact"
+//	  - lv: "kontakti"
+//
+// "/lv/kontakti" and "/en/contact" both reach wrapped handler as "/contact".
+// Use NewRouter to construct Router.
+type Router struct {
+	Locale        *Locale     // Locale with initialized languages.
+	Negotiator    *Middleware // Used to negotiate language for paths without language prefix.
+	SegmentPrefix string      // Translation key prefix for translated path segments (empty disables translation).
+	SkipPrefixes  []string    // Paths with these prefixes are passed through without language handling ("/static/").
+	RedirectCode  int         // HTTP status code for redirects to localized paths.
+
+	localized map[string]map[string]string // language -> canonical segment -> translated segment
+	canonical map[string]map[string]string // language -> translated segment -> canonical segment
+}
+
+// NewRouter can be used to construct Router. Paths without language prefix
+// get redirected to language negotiated by query parameter "lang", cookie
+// "lang", Accept-Language and default language (see NewMiddleware).
+// Translated path segments are read from Locale on construction, call
+// Router.Reload if translations change.
+//
+// Params:
+// locale - Locale with initialized languages.
+// segmentPrefix - translation key prefix for translated path segments, for example, "route.".
+func NewRouter(locale *Locale, segmentPrefix string) *Router {
+	negotiator := NewMiddleware(locale)
+	negotiator.Sources = []LanguageSource{SourceQuery, SourceCookie, SourceHeader}
+
+	router := &Router{
+		Locale:        locale,
+		Negotiator:    negotiator,
+		SegmentPrefix: segmentPrefix,
+		RedirectCode:  http.StatusFound,
+	}
+
+	router.Reload()
+
+	return router
+}
+
+// Reload rebuilds translated path segments from Locale translations.
+// Not safe for concurrent use with request handling.
+func (rt *Router) Reload() {
+	rt.localized = make(map[string]map[string]string, len(rt.Locale.Languages))
+	rt.canonical = make(map[string]map[string]string, len(rt.Locale.Languages))
+
+	if rt.SegmentPrefix == "" {
+		return
+	}
+
+	index := rt.Locale.getIndex()
+
+	for k, lang := range rt.Locale.Languages {
+		localized := make(map[string]string)
+		canonical := make(map[string]string)
+
+		// Language and its parent languages only ("en-GB" -> "en"), segments
+		// without translation stay canonical instead of using other language.
+		chain := index.chain(k, true)
+
+		for i := len(chain) - 1; i >= 0; i-- {
+			for key, value := range chain[i].Map {
+				if !strings.HasPrefix(key, rt.SegmentPrefix) || value[0] == "" {
+					continue
+				}
+
+				name := strings.TrimPrefix(key, rt.SegmentPrefix)
+
+				delete(canonical, localized[name])
+				localized[name] = value[0]
+				canonical[value[0]] = name
+			}
+		}
+
+		rt.localized[lang.Keyword] = localized
+		rt.canonical[lang.Keyword] = canonical
+	}
+}
+
+// Handler wraps passed handler with language prefix handling. Wrapped handler
+// receives request with path without language prefix and with canonical path
+// segments ("/lv/kontakti" -> "/contact").
+func (rt *Router) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, v := range rt.SkipPrefixes {
+			if strings.HasPrefix(r.URL.Path, v) {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		prefix := pathPrefix(r.URL.Path)
+		index := rt.Locale.getIndex()
+
+		idx, exist := index.resolve(prefix)
+		if !exist || prefix == "" {
+			// No language prefix - redirect to negotiated language.
+			w.Header().Add("Vary", "Accept-Language")
+			rt.redirect(w, r, rt.Negotiator.Negotiate(r).Language(), r.URL.Path)
+
+			return
+		}
+
+		lang := rt.Locale.Languages[idx].Keyword
+		path := rt.canonicalPath(lang, strings.TrimPrefix(r.URL.Path, "/"+prefix))
+
+		if prefix != lang {
+			// Not canonical language prefix ("/EN/about", "/en-US/about" -> "/en/about").
+			rt.redirect(w, r, lang, path)
+			return
+		}
+
+		w.Header().Set("Content-Language", lang)
+
+		ctx := WithLocalizer(r.Context(), Localizer{locale: rt.Locale, language: lang})
+		ctx = context.WithValue(ctx, routePathContextKey{}, path)
+
+		request := r.WithContext(ctx)
+		request.URL = cloneURLWithPath(r, path)
+
+		next.ServeHTTP(w, request)
+	})
+}
+
+// URL returns localized URL path for passed canonical path, for example,
+// URL("lv", "/contact") -> "/lv/kontakti".
+// Query string (if any) is kept as is.
+//
+// Params:
+// lang - language keyword (regional variants resolve to parent language).
+// path - canonical path without language prefix.
+func (rt *Router) URL(lang, path string) string {
+	idx, exist := rt.Locale.getIndex().resolve(lang)
+	if exist {
+		lang = rt.Locale.Languages[idx].Keyword
+	}
+
+	query := ""
+
+	end := strings.IndexByte(path, '?')
+	if end >= 0 {
+		path, query = path[:end], path[end:]
+	}
+
+	return "/" + lang + rt.localizedPath(lang, path) + query
+}
+
+// CurrentURL returns URL of current request in passed language, for example,
+// for language switcher links. Query string is kept.
+// Request can be either request received by Router wrapped handler or
+// original request with language prefix.
+func (rt *Router) CurrentURL(r *http.Request, lang string) string {
+	path, ok := r.Context().Value(routePathContextKey{}).(string)
+	if !ok {
+		path = r.URL.Path
+
+		prefix := pathPrefix(path)
+
+		idx, exist := rt.Locale.getIndex().resolve(prefix)
+		if exist && prefix != "" {
+			path = rt.canonicalPath(rt.Locale.Languages[idx].Keyword, strings.TrimPrefix(path, "/"+prefix))
+		}
+	}
+
+	if r.URL.RawQuery != "" {
+		path += "?" + r.URL.RawQuery
+	}
+
+	return rt.URL(lang, path)
+}
+
+// redirect redirects request to localized path.
+// Responds with 404 if there is no language to redirect to.
+func (rt *Router) redirect(w http.ResponseWriter, r *http.Request, lang, path string) {
+	if lang == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	target := rt.URL(lang, path)
+	if r.URL.RawQuery != "" {
+		target += "?" + r.URL.RawQuery
+	}
+
+	code := rt.RedirectCode
+	if code == 0 {
+		code = http.StatusFound
+	}
+
+	http.Redirect(w, r, target, code)
+}
+
+// canonicalPath replaces translated path segments with canonical segments.
+// Returns "/" for empty path.
+func (rt *Router) canonicalPath(lang, path string) string {
+	return translatePath(path, rt.canonical[lang])
+}
+
+// localizedPath replaces canonical path segments with translated segments.
+// Returns "/" for empty path.
+func (rt *Router) localizedPath(lang, path string) string {
+	return translatePath(path, rt.localized[lang])
+}
+
+// translatePath replaces path segments found in passed map. Segments not
+// found in map are kept as is.
+// Returns "/" for empty path.
+func translatePath(path string, segments map[string]string) string {
+	if path == "" || path == "/" {
+		return "/"
+	}
+
+	if len(segments) == 0 {
+		return path
+	}
+
+	parts := strings.Split(path, "/")
+
+	for k, v := range parts {
+		translated, exist := segments[v]
+		if exist {
+			parts[k] = translated
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// cloneURLWithPath returns copy of request URL with passed path.
+func cloneURLWithPath(r *http.Request, path string) *url.URL {
+	clone := *r.URL
+	clone.Path = path
+	clone.RawPath = ""
+
+	return &clone
+}
@@ -0,0 +1,367 @@
+package localization
+
+import (
+	"fmt"
+	"golang.org/x/net/html"
+	"html/template"
+	"strings"
+)
+
+// HTMLKeySuffix marks translation keys containing trusted HTML, for example,
+// "terms_html". Translations of such keys are returned as template.HTML after
+// sanitizing with Locale.HTMLPolicy (see Localizer.HTML).
+const HTMLKeySuffix = "_html"
+
+// HTMLPolicy is sanitizer allowlist for HTML translations. Elements not in
+// allowlist are removed (text inside is kept, except for script and style
+// elements), attributes not in allowlist are removed.
+// Use DefaultHTMLPolicy for basic text formatting and links.
+type HTMLPolicy struct {
+	Elements   map[string][]string // Allowed elements with allowed attributes ("a": {"href", "title"}).
+	URLSchemes []string            // Allowed URL schemes for href and src attributes, relative URLs are always allowed.
+}
+
+// DefaultHTMLPolicy returns HTMLPolicy which allows basic text formatting
+// elements (b, strong, i, em, u, s, small, sub, sup, code, br, span, p) and
+// links with http, https and mailto URLs.
+func DefaultHTMLPolicy() *HTMLPolicy {
+	return &HTMLPolicy{
+		Elements: map[string][]string{
+			"a":      {"href", "title", "target", "rel"},
+			"b":      nil,
+			"br":     nil,
+			"code":   nil,
+			"em":     nil,
+			"i":      nil,
+			"p":      nil,
+			"s":      nil,
+			"small":  nil,
+			"span":   {"class"},
+			"strong": nil,
+			"sub":    nil,
+			"sup":    nil,
+			"u":      nil,
+		},
+		URLSchemes: []string{"http", "https", "mailto"},
+	}
+}
+
+// Sanitize returns value with elements and attributes not allowed by policy
+// removed. Text is always escaped. Output is always well-formed: end tags
+// without matching start tag are removed, misnested inner elements are closed
+// before outer ones and unclosed elements are closed at the end.
+func (p *HTMLPolicy) Sanitize(value string) string {
+	builder := strings.Builder{}
+	builder.Grow(len(value))
+
+	tokenizer := html.NewTokenizer(strings.NewReader(value))
+	open := make([]string, 0)
+	skip := 0
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			// Only io.EOF is possible when reading from string.
+			closeElements(&builder, open)
+			return builder.String()
+		}
+
+		token := tokenizer.Token()
+
+		switch tokenType {
+		case html.TextToken:
+			if skip == 0 {
+				builder.WriteString(html.EscapeString(token.Data))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if isRawTextElement(token.Data) && tokenType == html.StartTagToken {
+				skip++
+				continue
+			}
+
+			attributes, allowed := p.Elements[token.Data]
+			if !allowed || skip > 0 {
+				continue
+			}
+
+			p.writeStartTag(&builder, token, attributes)
+
+			if isVoidElement(token.Data) {
+				continue
+			}
+
+			// Self-closing non-void element ("<b/>") is start and end tag.
+			if tokenType == html.SelfClosingTagToken {
+				builder.WriteString("</" + token.Data + ">")
+				continue
+			}
+
+			open = append(open, token.Data)
+		case html.EndTagToken:
+			if isRawTextElement(token.Data) {
+				if skip > 0 {
+					skip--
+				}
+
+				continue
+			}
+
+			if skip > 0 {
+				continue
+			}
+
+			idx := lastIndexString(open, token.Data)
+			if idx < 0 {
+				continue
+			}
+
+			// Inner elements get closed first ("<b><i>x</b>" -> "<b><i>x</i></b>").
+			closeElements(&builder, open[idx:])
+			open = open[:idx]
+		}
+	}
+}
+
+// closeElements writes end tags of open elements in reverse order.
+func closeElements(builder *strings.Builder, open []string) {
+	for k := len(open) - 1; k >= 0; k-- {
+		builder.WriteString("</" + open[k] + ">")
+	}
+}
+
+// writeStartTag writes start tag with allowed attributes.
+func (p *HTMLPolicy) writeStartTag(builder *strings.Builder, token html.Token, attributes []string) {
+	builder.WriteString("<" + token.Data)
+
+	for _, v := range token.Attr {
+		if v.Namespace != "" || !containsString(attributes, v.Key) {
+			continue
+		}
+
+		if (v.Key == "href" || v.Key == "src") && !p.isAllowedURL(v.Val) {
+			continue
+		}
+
+		builder.WriteString(" " + v.Key + `="` + html.EscapeString(v.Val) + `"`)
+	}
+
+	builder.WriteString(">")
+}
+
+// element returns tag renderer which wraps content in HTML element with passed
+// attributes, URL attributes are checked with isAllowedURL (see HTMLElement).
+// Returns error (ErrHTMLElement) if element or attributes are not valid.
+func (p *HTMLPolicy) element(name string, attributes ...string) (RichTag, error) {
+	name = strings.ToLower(name)
+
+	if !isHTMLName(name, false) || isRawTextElement(name) || name == "object" || name == "embed" {
+		return nil, fmt.Errorf("%w: element '%s'", ErrHTMLElement, name)
+	}
+
+	if len(attributes)%2 != 0 {
+		return nil, fmt.Errorf("%w: attributes must be name and value pairs, got %d values",
+			ErrHTMLElement, len(attributes))
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString("<" + name)
+
+	for k := 0; k < len(attributes); k += 2 {
+		key, value := strings.ToLower(attributes[k]), attributes[k+1]
+
+		if !isHTMLName(key, true) || strings.HasPrefix(key, "on") {
+			return nil, fmt.Errorf("%w: attribute '%s' of '%s'", ErrHTMLElement, attributes[k], name)
+		}
+
+		if isURLAttribute(key) && !p.isAllowedURL(value) {
+			return nil, fmt.Errorf("%w: URL '%s' of '%s' is not allowed", ErrHTMLElement, value, name)
+		}
+
+		builder.WriteString(" " + key + `="` + template.HTMLEscapeString(value) + `"`)
+	}
+
+	builder.WriteString(">")
+
+	start, end := builder.String(), "</"+name+">"
+
+	return func(content string) string {
+		return start + content + end
+	}, nil
+}
+
+// isAllowedURL checks if URL is relative or has allowed scheme.
+func (p *HTMLPolicy) isAllowedURL(value string) bool {
+	value = strings.TrimSpace(value)
+
+	end := strings.IndexAny(value, ":/?#")
+	if end < 0 || value[end] != ':' {
+		// Relative URL.
+		return true
+	}
+
+	for _, v := range p.URLSchemes {
+		if strings.EqualFold(value[:end], v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HTML returns translation as template.HTML, formatted with passed args
+// (see Textf). Args are always HTML escaped. Translations of keys with
+// HTMLKeySuffix are sanitized with Locale.HTMLPolicy (DefaultHTMLPolicy if
+// not set), translations of other keys are HTML escaped.
+// Returns missing key text (see Locale.MissingBehavior) if key does not exist.
+func (lz Localizer) HTML(key string, args ...interface{}) template.HTML {
+	text, _ := lz.html(key, args...)
+	return text
+}
+
+// HTMLNamed returns translation as template.HTML with named placeholders
+// replaced by passed args (see Localizer.TNamed). Args are always HTML
+// escaped, translation is sanitized or escaped as with Localizer.HTML.
+func (lz Localizer) HTMLNamed(key string, args map[string]interface{}) template.HTML {
+	text, _ := lz.htmlNamed(key, args)
+	return text
+}
+
+// html returns formatted, sanitized translation or escaped missing key text
+// and lookup error.
+func (lz Localizer) html(key string, args ...interface{}) (template.HTML, error) {
+	text, err := lz.Value(key)
+	return lz.formatHTML(key, text, err, args)
+}
+
+// htmlPlural returns formatted (with count and args), sanitized plural or
+// non-plural translation or escaped missing key text and lookup error.
+func (lz Localizer) htmlPlural(key string, count int, args ...interface{}) (template.HTML, error) {
+	text, err := lz.pluralValue(key, count)
+	return lz.formatHTML(key, text, err, append([]interface{}{count}, args...))
+}
+
+// formatHTML formats text with args and sanitizes (HTML keys, args escaped
+// before formatting) or escapes (other keys) result. Returns escaped missing
+// key text if lookup failed.
+func (lz Localizer) formatHTML(key, text string, err error, args []interface{}) (template.HTML, error) {
+	if err != nil {
+		return template.HTML(template.HTMLEscapeString(lz.missing(key))), err
+	}
+
+	if len(args) > 0 && isHTMLKey(key) {
+		text = fmt.Sprintf(text, escapeHTMLArgs(args)...)
+	} else if len(args) > 0 {
+		text = fmt.Sprintf(text, args...)
+	}
+
+	return lz.sanitize(key, text), nil
+}
+
+// htmlNamed returns sanitized translation with named placeholders replaced,
+// or escaped missing key text and lookup error.
+func (lz Localizer) htmlNamed(key string, args map[string]interface{}) (template.HTML, error) {
+	text, err := lz.Value(key)
+	if err != nil {
+		return template.HTML(template.HTMLEscapeString(lz.missing(key))), err
+	}
+
+	if !isHTMLKey(key) {
+		return lz.sanitize(key, formatNamed(text, args)), nil
+	}
+
+	escaped := make(map[string]interface{}, len(args))
+	for k, v := range args {
+		escaped[k] = escapeHTMLArg(v)
+	}
+
+	return lz.sanitize(key, formatNamed(text, escaped)), nil
+}
+
+// sanitize sanitizes text of HTML key or escapes text of other keys.
+func (lz Localizer) sanitize(key, text string) template.HTML {
+	if !isHTMLKey(key) {
+		return template.HTML(template.HTMLEscapeString(text))
+	}
+
+	return template.HTML(lz.htmlPolicy().Sanitize(text))
+}
+
+// htmlPolicy returns Locale.HTMLPolicy or DefaultHTMLPolicy if it is not set.
+func (lz Localizer) htmlPolicy() *HTMLPolicy {
+	if lz.locale == nil || lz.locale.HTMLPolicy == nil {
+		return DefaultHTMLPolicy()
+	}
+
+	return lz.locale.HTMLPolicy
+}
+
+// isHTMLKey checks if translation key is marked as trusted HTML.
+func isHTMLKey(key string) bool {
+	return strings.HasSuffix(key, HTMLKeySuffix)
+}
+
+// escapeHTMLArgs returns args with string values HTML escaped.
+func escapeHTMLArgs(args []interface{}) []interface{} {
+	escaped := make([]interface{}, len(args))
+
+	for k, v := range args {
+		escaped[k] = escapeHTMLArg(v)
+	}
+
+	return escaped
+}
+
+// escapeHTMLArg returns HTML escaped arg. Numbers and booleans are kept as is,
+// so numeric format verbs keep working, other values are converted to string.
+func escapeHTMLArg(arg interface{}) interface{} {
+	switch arg.(type) {
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return arg
+	default:
+		return template.HTMLEscapeString(fmt.Sprint(arg))
+	}
+}
+
+// isVoidElement checks if element has no end tag.
+func isVoidElement(name string) bool {
+	switch name {
+	case "br", "hr", "img", "wbr":
+		return true
+	}
+
+	return false
+}
+
+// isRawTextElement checks if element content must be removed with element.
+func isRawTextElement(name string) bool {
+	switch name {
+	case "script", "style", "iframe", "noscript", "textarea", "title", "xmp", "noembed", "noframes", "template":
+		return true
+	}
+
+	return false
+}
+
+// lastIndexString returns index of the last value occurrence in values or -1
+// if values do not contain value.
+func lastIndexString(values []string, value string) int {
+	for k := len(values) - 1; k >= 0; k-- {
+		if values[k] == value {
+			return k
+		}
+	}
+
+	return -1
+}
+
+// containsString checks if values contain value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,64 +2,65 @@ package localization
 
 import (
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
 )
 
 func TestParseAcceptLanguage(t *testing.T) {
 	testCases := []struct {
 		value    string
-		expected *AcceptLanguages
+		expected []AcceptEntry
 	}{
 		{
 			"en-US,en;q=0.5",
-			&AcceptLanguages{
-				[]PriorityGroup{
-					{0.5, []string{"en-US", "en"}},
-				},
-			},
+			[]AcceptEntry{{"en-US", 1}, {"en", 0.5}},
 		},
 		{
 			"*",
-			&AcceptLanguages{
-				[]PriorityGroup{
-					{.0, []string{"*"}},
-				},
-			},
+			[]AcceptEntry{{"*", 1}},
 		},
 		{
 			"fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0",
-			&AcceptLanguages{
-				[]PriorityGroup{
-					{0.9, []string{"fr-CH", "fr"}},
-					{0.8, []string{"en"}},
-					{0.7, []string{"de"}},
-					{.0, []string{"*"}},
-				},
-			},
+			[]AcceptEntry{{"fr-CH", 1}, {"fr", 0.9}, {"en", 0.8}, {"de", 0.7}, {"*", 0}},
 		},
 		{
 			"*;q=0",
-			&AcceptLanguages{
-				[]PriorityGroup{
-					{.0, []string{"*"}},
-				},
-			},
+			[]AcceptEntry{{"*", 0}},
 		},
 		{
-			"*",
-			&AcceptLanguages{
-				[]PriorityGroup{
-					{.0, []string{"*"}},
-				},
-			},
+			"fil, zh-Hant-TW;q=0.9, es-419;q=0.9, sr-Latn;Q=0.8",
+			[]AcceptEntry{{"fil", 1}, {"zh-Hant-TW", 0.9}, {"es-419", 0.9}, {"sr-Latn", 0.8}},
+		},
+		{
+			" en-GB ; q=1.000 ,, \tlv;q=0.",
+			[]AcceptEntry{{"en-GB", 1}, {"lv", 0}},
+		},
+		{ // Not valid entries are skipped.
+			"en;q=2, de;q=0.5000, fr;q=abc, 419, e n, lt;x=1, lv;q=1.001, et-, ru;q=0.123",
+			[]AcceptEntry{{"ru", 0.123}},
+		},
+		{
+			"en;q=0.5, ;q=0.5",
+			[]AcceptEntry{{"en", 0.5}},
+		},
+		{ // Equal weights keep header order.
+			"lv;q=0.8, en, de;q=0.8, fr;q=0.9, lt;q=0.8",
+			[]AcceptEntry{{"en", 1}, {"fr", 0.9}, {"lv", 0.8}, {"de", 0.8}, {"lt", 0.8}},
 		},
 		{
-			"", &AcceptLanguages{nil},
+			"", nil,
+		},
+		{
+			"en, " + strings.Repeat("a", maxAcceptLanguageLength), nil,
+		},
+		{
+			"q;q=0", []AcceptEntry{{"q", 0}},
 		},
 	}
 
 	for k, v := range testCases {
-		lang := ParseAcceptLanguage(v.value)
+		lang := slices.Collect(ParseAcceptLanguage(v.value).Entries())
 
 		if !reflect.DeepEqual(v.expected, lang) {
 			t.Fatalf("unexpected result, index=%d, input=%s, expected=%v, actual=%v",
@@ -68,75 +69,221 @@ func TestParseAcceptLanguage(t *testing.T) {
 	}
 }
 
+func TestParseAcceptLanguage_EntryLimit(t *testing.T) {
+	value := strings.Repeat("en,", maxAcceptLanguageEntries) + "lv"
+
+	lang := ParseAcceptLanguage(value)
+	if lang.Len() != maxAcceptLanguageEntries {
+		t.Fatalf("unexpected result, actual=%v", lang)
+	}
+
+	for v := range lang.Entries() {
+		if v.Range != "en" {
+			t.Fatalf("unexpected entry, actual=%v", v)
+		}
+	}
+}
+
+func FuzzParseAcceptLanguage(f *testing.F) {
+	f.Add("en-US,en;q=0.5")
+	f.Add("fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0")
+	f.Add("zh-Hant-TW;q=0.9, es-419;q=1.000, fil")
+	f.Add(" ,;q=, en;;q=0.5")
+
+	f.Fuzz(func(t *testing.T, value string) {
+		lang := ParseAcceptLanguage(value)
+		entries := slices.Collect(lang.Entries())
+
+		for k, v := range entries {
+			if v.Quality < 0 || v.Quality > 1 {
+				t.Fatalf("unexpected weight, input=%q, weight=%f", value, v.Quality)
+			}
+
+			if k > 0 && v.Quality > entries[k-1].Quality {
+				t.Fatalf("unexpected order, input=%q, entries=%v", value, entries)
+			}
+
+			if !isLanguageRange(v.Range) {
+				t.Fatalf("unexpected language range, input=%q, range=%q", value, v.Range)
+			}
+		}
+
+		if len(entries) > maxAcceptLanguageEntries {
+			t.Fatalf("unexpected entry count, input=%q, count=%d", value, len(entries))
+		}
+
+		_ = lang.Lookup([]string{"en", "lv", "zh-Hant"}, "en")
+		_ = lang.Filter([]string{"en", "lv", "zh-Hant"})
+	})
+}
+
 func TestAcceptLanguages_FindFirstMatchingLang(t *testing.T) {
 	testCases := []struct {
-		enabledLangs    []string
-		defaultLang     string
-		acceptLanguages AcceptLanguages
-		expected        string
+		enabledLangs []string
+		defaultLang  string
+		value        string
+		expected     string
 	}{
+		{[]string{"lv", "en"}, "lv", "en-US;q=0.5, en;q=0.5", "en"},
+		{[]string{"lv", "en"}, "lv", "ee;q=0.5, lt;q=0.5, *;q=0.4, es;q=0", "lv"},
+		{[]string{"lv", "en"}, "en", "ee;q=0.5, lt;q=0.5, *;q=0.4, lv;q=0", "en"},
+		{[]string{"lv", "en"}, "lv", "en-US;q=0.5, en;q=0.5, ee;q=0.4, lt;q=0", "en"},
+		{[]string{"de", "fr"}, "lv", "en-US;q=0.5, en;q=0.5, ee;q=0.4, lt;q=0", ""},
+		{ // Prefix truncation "en-US" -> "en".
+			[]string{"lv", "en"}, "lv", "en-US", "en",
+		},
+		{ // Prefix truncation "de-CH-1996" -> "de".
+			[]string{"lv", "de"}, "lv", "de-CH-1996;q=0.9, lv;q=0.8", "de",
+		},
+		{ // Excluded with q=0.
+			[]string{"lv", "en"}, "lv", "en-US;q=0.9, en;q=0, lv;q=0.5", "lv",
+		},
+		{ // Asterisk excluded with q=0.
+			[]string{"lv", "en"}, "lv", "de;q=0.9, *;q=0", "",
+		},
+		{ // Equal weights keep header order.
+			[]string{"lv", "en"}, "lv", "lt;q=0.8, en;q=0.8, lv;q=0.8", "en",
+		},
+	}
+
+	for k, v := range testCases {
+		lang := ParseAcceptLanguage(v.value).FindFirstMatchingLang(v.enabledLangs, v.defaultLang)
+
+		if v.expected != lang {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s",
+				k, v.expected, lang)
+		}
+	}
+}
+
+func TestAcceptLanguages_Lookup(t *testing.T) {
+	testCases := []struct {
+		value        string
+		enabledLangs []string
+		defaultLang  string
+		expected     []LanguageMatch
+	}{
+		{
+			"fr-CH;q=0.9, en-GB;q=0.8, de;q=0.7, *;q=0.5", []string{"lv", "en", "fr"}, "lv",
+			[]LanguageMatch{{"fr", "fr-CH", 0.9}, {"en", "en-GB", 0.8}, {"lv", "*", 0.5}},
+		},
+		{
+			"en-US;q=0.9, en;q=0.8", []string{"en-us", "EN"}, "",
+			[]LanguageMatch{{"en-us", "en-US", 0.9}, {"EN", "en", 0.8}},
+		},
 		{
-			[]string{"lv", "en"}, "lv",
-			AcceptLanguages{
-				[]PriorityGroup{
-					{0.5, []string{"en-US", "en"}},
-				},
-			},
-			"en",
-		},
-		{
-			[]string{"lv", "en"}, "lv",
-			AcceptLanguages{
-				[]PriorityGroup{
-					{0.5, []string{"ee", "lt"}},
-					{0.4, []string{"*"}},
-					{.0, []string{"es"}},
-				},
-			},
-			"lv",
-		},
-		{
-			[]string{"lv", "en"}, "en",
-			AcceptLanguages{
-				[]PriorityGroup{
-					{0.5, []string{"ee", "lt"}},
-					{0.4, []string{"*"}},
-					{.0, []string{"lv"}},
-				},
-			},
-			"en",
-		},
-		{
-			[]string{"lv", "en"}, "lv",
-			AcceptLanguages{
-				[]PriorityGroup{
-					{0.5, []string{"en-US", "en"}},
-					{0.4, []string{"ee"}},
-					{.0, []string{"lt"}},
-				},
-			},
-			"en",
-		},
-		{
-			[]string{"de", "fr"}, "lv",
-			AcceptLanguages{
-				[]PriorityGroup{
-					{0.5, []string{"en-US", "en"}},
-					{0.4, []string{"ee"}},
-					{.0, []string{"lt"}},
-				},
-			},
-			"",
+			"en-GB;q=0.9, en;q=0", []string{"en-GB", "en"}, "",
+			[]LanguageMatch{{"en-GB", "en-GB", 0.9}},
+		},
+		{
+			"en-US;q=0.9, en;q=0, *;q=0.5", []string{"en", "lv"}, "en",
+			[]LanguageMatch{{"lv", "*", 0.5}},
+		},
+		{ // Asterisk expands to not listed languages, default language first.
+			"en;q=0.1, *;q=0.5", []string{"lv", "en", "lt", "de"}, "de",
+			[]LanguageMatch{{"de", "*", 0.5}, {"lv", "*", 0.5}, {"lt", "*", 0.5}, {"en", "en", 0.1}},
+		},
+		{ // Listed languages (by filtering and lookup) are not expanded.
+			"en-GB;q=0.3, de;q=0, *", []string{"lv", "en", "de-CH"}, "",
+			[]LanguageMatch{{"lv", "*", 1}, {"en", "en-GB", 0.3}},
+		},
+		{
+			"", []string{"en", "lv"}, "en",
+			[]LanguageMatch{},
 		},
 	}
 
 	for k, v := range testCases {
-		lang := v.acceptLanguages.FindFirstMatchingLang(v.enabledLangs, v.defaultLang)
+		matches := ParseAcceptLanguage(v.value).Lookup(v.enabledLangs, v.defaultLang)
+
+		if !reflect.DeepEqual(v.expected, matches) {
+			t.Fatalf("unexpected result, index=%d, input=%s, expected=%v, actual=%v",
+				k, v.value, v.expected, matches)
+		}
+	}
+}
+
+func TestAcceptLanguages_Reuse(t *testing.T) {
+	accept := ParseAcceptLanguage("de;q=0.9, *;q=0.5")
+	entries := slices.Collect(accept.Entries())
+
+	testCases := []struct {
+		defaultLang string
+		expected    string
+	}{
+		{"lv", "lv"},
+		{"en", "en"},
+		{"", "lv"},
+		{"lt", "lv"},
+	}
+
+	for k, v := range testCases {
+		lang := accept.FindFirstMatchingLang([]string{"lv", "en"}, v.defaultLang)
 
 		if v.expected != lang {
 			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s",
 				k, v.expected, lang)
 		}
+
+		if !reflect.DeepEqual(entries, slices.Collect(accept.Entries())) {
+			t.Fatalf("unexpected entries modification, index=%d, expected=%v, actual=%v",
+				k, entries, slices.Collect(accept.Entries()))
+		}
+	}
+}
+
+func TestAcceptLanguages_Filter(t *testing.T) {
+	testCases := []struct {
+		value        string
+		enabledLangs []string
+		expected     []LanguageMatch
+	}{
+		{
+			"en;q=0.9, lv;q=0.8", []string{"lv", "en-GB", "en", "de"},
+			[]LanguageMatch{{"en-GB", "en", 0.9}, {"en", "en", 0.9}, {"lv", "lv", 0.8}},
+		},
+		{
+			"en;q=0.9, en-GB;q=0, *;q=0.5", []string{"lv", "en-GB", "en"},
+			[]LanguageMatch{{"en", "en", 0.9}, {"lv", "*", 0.5}},
+		},
+		{
+			"en-GB;q=0.9, en;q=0.5", []string{"en", "en-GB-oxendict"},
+			[]LanguageMatch{{"en-GB-oxendict", "en-GB", 0.9}, {"en", "en", 0.5}},
+		},
+		{
+			"*;q=0", []string{"lv", "en"},
+			[]LanguageMatch{},
+		},
+	}
+
+	for k, v := range testCases {
+		matches := ParseAcceptLanguage(v.value).Filter(v.enabledLangs)
+
+		if !reflect.DeepEqual(v.expected, matches) {
+			t.Fatalf("unexpected result, index=%d, input=%s, expected=%v, actual=%v",
+				k, v.value, v.expected, matches)
+		}
+	}
+}
+
+func TestTruncateRange(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{"zh-Hant-CN-x-private1", "zh-Hant-CN"},
+		{"zh-Hant-CN", "zh-Hant"},
+		{"de-CH-1996", "de-CH"},
+		{"de", ""},
+		{"", ""},
 	}
 
+	for k, v := range testCases {
+		received := truncateRange(v.value)
+
+		if v.expected != received {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s",
+				k, v.expected, received)
+		}
+	}
 }
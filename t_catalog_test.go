@@ -0,0 +1,55 @@
+package localization
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadCatalog(t *testing.T) {
+	tempDir := t.TempDir()
+
+	_ = os.WriteFile(filepath.Join(tempDir, "a.yml"), []byte(`
+title:
+  - lv: "Virsraksts"
+  - en_gb: "Title"
+only_default: "Default"
+`), 0o644)
+	_ = os.WriteFile(filepath.Join(tempDir, "b.yml"), []byte(`
+title:
+  - de: "Titel"
+`), 0o644)
+	_ = os.Mkdir(filepath.Join(tempDir, "dir.yml"), 0o755)
+
+	locale, err := LoadCatalog("en", filepath.Join(tempDir, "*.yml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{"en", "lv", "en-GB", "de"}
+	if !reflect.DeepEqual(locale.EnabledLanguages(), expected) {
+		t.Fatalf("unexpected languages, expected=%v, actual=%v", expected, locale.EnabledLanguages())
+	}
+
+	testCases := []struct {
+		key      string
+		expected []string
+	}{
+		{"title", []string{"en"}},
+		{"only_default", []string{"lv", "de"}},
+		{"none", []string{"en", "lv", "en-GB", "de"}},
+	}
+
+	for k, v := range testCases {
+		received := locale.MissingLanguages(v.key)
+		if !reflect.DeepEqual(received, v.expected) {
+			t.Fatalf("unexpected result, index=%d, expected=%v, actual=%v", k, v.expected, received)
+		}
+	}
+
+	_, err = LoadCatalog("en", filepath.Join(tempDir, "missing*.yml"))
+	if err == nil {
+		t.Fatalf("expected failure for pattern without matches")
+	}
+}
@@ -0,0 +1,50 @@
+package localization
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	locale0, _ := NewLocale(false, "lv", "en")
+	locale0.SetValueNoErr("en", "key0", "Hello", "")
+	locale0.SetValueNoErr("en", "key1", "Hello, %s!", "")
+	locale0.SetValueNoErr("en", "key2", "%d item", "%d items")
+	locale0.SetValueNoErr("en", "key3", "Hello, {name}!", "")
+	locale0.SetValueNoErr("lv", "key0", "Sveiki", "")
+
+	defer defaultLocalizer.Store(nil)
+
+	ctxEn := WithLocalizer(context.Background(), locale0.For("en"))
+	var nilCtx context.Context
+
+	received := FromContext(context.Background()).T("key0")
+	if received != "" {
+		t.Fatalf("unexpected result without default, actual=%s", received)
+	}
+
+	SetDefaultLocalizer(locale0.For("lv"))
+
+	testCases := []struct {
+		received string
+		expected string
+	}{
+		{FromContext(ctxEn).Language(), "en"},
+		{FromContext(context.Background()).Language(), "lv"},
+		{FromContext(nilCtx).Language(), "lv"},
+		{TextCtx(ctxEn, "key0"), "Hello"},
+		{TextCtx(context.Background(), "key0"), "Sveiki"},
+		{TextCtx(ctxEn, "key1", "John"), "Hello, John!"},
+		{TextCtx(ctxEn, "keyX"), ""},
+		{TextPluralCtx(ctxEn, "key2", 1), "1 item"},
+		{TextPluralCtx(ctxEn, "key2", 5), "5 items"},
+		{TextNamedCtx(ctxEn, "key3", map[string]interface{}{"name": "John"}), "Hello, John!"},
+	}
+
+	for k, v := range testCases {
+		if v.received != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s",
+				k, v.expected, v.received)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package localization
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLocale_Coverage(t *testing.T) {
+	locale0, _ := NewLocale(false, "en", "lv", "en-GB")
+	locale0.SetValueNoErr("en", "key0", "Hello", "")
+	locale0.SetValueNoErr("en", "key1", "%d item", "%d items")
+	locale0.SetValueNoErr("en", "key2", "OK", "")
+	locale0.SetValueNoErr("en", "key3", "Bye", "")
+	locale0.SetValueNoErr("lv", "key0", "Sveiki", "")
+	locale0.SetValueNoErr("lv", "key1", "%d lieta", "")
+	locale0.SetValueNoErr("lv", "key2", "OK", "")
+	locale0.SetValueNoErr("lv", "key3", "", "")
+	locale0.SetValueNoErr("lv", "extra", "Lieks", "")
+	locale0.SetValueNoErr("en-GB", "key0", "Hello", "")
+
+	report, err := locale0.Coverage("en")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := CoverageReport{Source: "en", Keys: 4, Languages: []LanguageCoverage{
+		{Language: "en", Keys: 4, Missing: []string{}, MissingPlurals: []string{}, Empty: []string{},
+			Identical: []string{}, Coverage: 100},
+		{Language: "lv", Keys: 5, Missing: []string{}, MissingPlurals: []string{"key1"}, Empty: []string{"key3"},
+			Identical: []string{"key2"}, Coverage: 75},
+		{Language: "en-GB", Keys: 1, Missing: []string{}, MissingPlurals: []string{}, Empty: []string{},
+			Identical: []string{"key0"}, Coverage: 100},
+	}}
+
+	if !reflect.DeepEqual(report, expected) {
+		t.Fatalf("unexpected result, expected=%v, actual=%v", expected, report)
+	}
+
+	below := report.Below(80)
+	if !reflect.DeepEqual(below, []string{"lv"}) {
+		t.Fatalf("unexpected result, expected=%v, actual=%v", []string{"lv"}, below)
+	}
+
+	_, err = locale0.Coverage("de")
+	if err == nil {
+		t.Fatalf("expected failure for unknown source language")
+	}
+}
+
+func TestLocale_CoverageMissing(t *testing.T) {
+	locale0, _ := NewLocale(false, "en", "lv")
+	locale0.SetValueNoErr("en", "key0", "Hello", "")
+	locale0.SetValueNoErr("en", "key1", "Bye", "")
+	locale0.SetValueNoErr("lv", "key0", "Sveiki", "")
+
+	report, _ := locale0.Coverage("en")
+
+	coverage := report.Languages[1]
+	if !reflect.DeepEqual(coverage.Missing, []string{"key1"}) || coverage.Coverage != 50 {
+		t.Fatalf("unexpected result, missing=%v, coverage=%v", coverage.Missing, coverage.Coverage)
+	}
+}
+
+func TestCoverageReport_Write(t *testing.T) {
+	report := CoverageReport{Source: "en", Keys: 2, Languages: []LanguageCoverage{
+		{Language: "en", Keys: 2, Missing: []string{}, MissingPlurals: []string{}, Empty: []string{},
+			Identical: []string{}, Coverage: 100},
+		{Language: "lv", Keys: 1, Missing: []string{"key1"}, MissingPlurals: []string{}, Empty: []string{},
+			Identical: []string{}, Coverage: 50},
+	}}
+
+	testCases := []struct {
+		write    func(buffer *bytes.Buffer) error
+		expected []string
+	}{
+		{func(buffer *bytes.Buffer) error { return report.WriteText(buffer) },
+			[]string{"Source: en (2 keys)", "LANGUAGE  KEYS  MISSING", "lv        1     1", "50.0%"}},
+		{func(buffer *bytes.Buffer) error { return report.WriteMarkdown(buffer) },
+			[]string{"Source: `en` (2 keys)", "| Language | Keys |", "| `lv` | 1 | 1 | 0 | 0 | 0 | 50.0% |"}},
+		{func(buffer *bytes.Buffer) error { return report.WriteJSON(buffer) },
+			[]string{`"source": "en"`, `"missing": [`, `"coverage": 50`}},
+	}
+
+	for k, v := range testCases {
+		buffer := bytes.Buffer{}
+
+		err := v.write(&buffer)
+		if err != nil {
+			t.Fatalf("unexpected error, index=%d: %s", k, err)
+		}
+
+		for _, expected := range v.expected {
+			if !strings.Contains(buffer.String(), expected) {
+				t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s", k, expected, buffer.String())
+			}
+		}
+	}
+
+	decoded := CoverageReport{}
+
+	buffer := bytes.Buffer{}
+	_ = report.WriteJSON(&buffer)
+
+	err := json.Unmarshal(buffer.Bytes(), &decoded)
+	if err != nil || !reflect.DeepEqual(decoded, report) {
+		t.Fatalf("unexpected result, expected=%v, actual=%v, err=%v", report, decoded, err)
+	}
+}
@@ -0,0 +1,137 @@
+package localization
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffLocales(t *testing.T) {
+	locale0, _ := NewLocale(false, "en", "lv")
+	locale0.SetValueNoErr("en", "key0", "Hello", "")
+	locale0.SetValueNoErr("en", "key1", "%d item", "%d items")
+	locale0.SetValueNoErr("en", "key2", "Bye", "")
+	locale0.SetValueNoErr("lv", "key0", "Sveiki", "")
+	locale0.SetValueNoErr("lv", "key2", "Atā", "")
+
+	locale1, _ := NewLocale(false, "en", "lv")
+	locale1.SetValueNoErr("en", "key0", "Hello", "")
+	locale1.SetValueNoErr("en", "key1", "%d item", "%d things")
+	locale1.SetValueNoErr("en", "key3", "New", "")
+	locale1.SetValueNoErr("lv", "key0", "Labdien", "")
+	locale1.SetValueNoErr("lv", "key1", "%d lieta", "%d lietas")
+
+	diff := DiffLocales(locale0, locale1)
+
+	expected := CatalogDiff{
+		AddedKeys:   []string{"key3"},
+		RemovedKeys: []string{"key2"},
+		Changes: []CatalogChange{
+			{Key: "key0", Language: "lv", Kind: ChangeChanged, Old: [2]string{"Sveiki", ""},
+				New: [2]string{"Labdien", ""}, ValueChanged: true},
+			{Key: "key1", Language: "en", Kind: ChangeChanged, Old: [2]string{"%d item", "%d items"},
+				New: [2]string{"%d item", "%d things"}, PluralChanged: true},
+			{Key: "key1", Language: "lv", Kind: ChangeAdded, New: [2]string{"%d lieta", "%d lietas"},
+				ValueChanged: true, PluralChanged: true},
+			{Key: "key2", Language: "en", Kind: ChangeRemoved, Old: [2]string{"Bye", ""}, ValueChanged: true},
+			{Key: "key2", Language: "lv", Kind: ChangeRemoved, Old: [2]string{"Atā", ""}, ValueChanged: true},
+			{Key: "key3", Language: "en", Kind: ChangeAdded, New: [2]string{"New", ""}, ValueChanged: true},
+		},
+	}
+
+	if !reflect.DeepEqual(diff, expected) {
+		t.Fatalf("unexpected result, expected=%v, actual=%v", expected, diff)
+	}
+
+	filtered := diff.Filter("lv")
+	if len(filtered.Changes) != 3 || !reflect.DeepEqual(filtered.AddedKeys, expected.AddedKeys) {
+		t.Fatalf("unexpected result, expected 3 lv changes, actual=%v", filtered.Changes)
+	}
+
+	testCases := []struct {
+		languages []string
+		expected  int
+	}{
+		{[]string{"LV"}, 3},
+		{[]string{"en_gb"}, 1},
+		{[]string{"EN-GB", "lv"}, 4},
+		{[]string{"en"}, 3},
+	}
+
+	diff.Changes = append(diff.Changes, CatalogChange{Key: "key4", Language: "en-GB", Kind: ChangeAdded})
+
+	for k, v := range testCases {
+		received := len(diff.Filter(v.languages...).Changes)
+		if received != v.expected {
+			t.Fatalf("unexpected change count, index=%d, expected=%d, actual=%d", k, v.expected, received)
+		}
+	}
+
+	if !DiffLocales(locale1, locale1).Empty() {
+		t.Fatalf("expected empty diff of the same Locale")
+	}
+}
+
+func TestCatalogChange_String(t *testing.T) {
+	testCases := []struct {
+		change   CatalogChange
+		expected string
+	}{
+		{CatalogChange{Key: "key0", Language: "lv", Kind: ChangeChanged, Old: [2]string{"a", ""},
+			New: [2]string{"b", ""}, ValueChanged: true}, `~ key0 (lv): "a" -> "b"`},
+		{CatalogChange{Key: "key0", Language: "lv", Kind: ChangeChanged, Old: [2]string{"a", "as"},
+			New: [2]string{"a", ""}, PluralChanged: true}, `~ key0 (lv): plural "as" -> ""`},
+		{CatalogChange{Key: "key0", Language: "lv", Kind: ChangeAdded, New: [2]string{"a", "as"}},
+			`+ key0 (lv): "a"; plural "as"`},
+		{CatalogChange{Key: "key0", Language: "en", Kind: ChangeRemoved, Old: [2]string{"a", ""}},
+			`- key0 (en): "a"`},
+	}
+
+	for k, v := range testCases {
+		received := v.change.String()
+		if received != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s", k, v.expected, received)
+		}
+	}
+}
+
+func TestDiffCatalogs(t *testing.T) {
+	tempDir := t.TempDir()
+	oldPath := filepath.Join(tempDir, "old.yml")
+	newPath := filepath.Join(tempDir, "new.yml")
+
+	_ = os.WriteFile(oldPath, []byte("key0:\n  - en: \"Hello\"\n  - lv: \"Sveiki\"\n"), 0o644)
+	_ = os.WriteFile(newPath, []byte("key0:\n  - en: \"Hello\"\n  - lv: \"Labdien\"\n"), 0o644)
+
+	diff, err := DiffCatalogs("en", []string{oldPath}, []string{newPath})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(diff.Changes) != 1 || diff.Changes[0].Source != (Source{File: newPath, Line: 3}) {
+		t.Fatalf("unexpected result, actual=%v", diff.Changes)
+	}
+
+	buffer := bytes.Buffer{}
+	_ = diff.WriteText(&buffer)
+
+	expected := "~ key0 (lv): \"Sveiki\" -> \"Labdien\"\n0 keys added, 0 keys removed, 1 value changes (0 plural)\n"
+	if buffer.String() != expected {
+		t.Fatalf("unexpected result, expected=%s, actual=%s", expected, buffer.String())
+	}
+
+	buffer.Reset()
+	_ = diff.WriteJSON(&buffer)
+
+	if !strings.Contains(buffer.String(), `"kind": "changed"`) {
+		t.Fatalf("unexpected result, actual=%s", buffer.String())
+	}
+
+	_, err = DiffCatalogs("en", []string{filepath.Join(tempDir, "none*.yml")}, []string{newPath})
+	if err == nil {
+		t.Fatalf("expected failure for missing old catalog")
+	}
+}
@@ -0,0 +1,163 @@
+package localization
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestLocale_Errors(t *testing.T) {
+	locale0, _ := NewLocale(true, "lv", "en", "en-GB")
+	locale0.SetValueNoErr("lv", "key0", "non-plural", "plural")
+
+	testCases := []struct {
+		call     func() error
+		target   error
+		expected error
+	}{
+		{
+			func() error { _, err := locale0.Value("lt", "key0"); return err },
+			ErrLanguageNotFound,
+			&LanguageError{Err: ErrLanguageNotFound, Language: "lt"},
+		},
+		{
+			func() error { _, err := locale0.GetLanguage("lt"); return err },
+			ErrLanguageNotFound,
+			&LanguageError{Err: ErrLanguageNotFound, Language: "lt"},
+		},
+		{
+			func() error { return locale0.SetValue("lt", "key0", "", "") },
+			ErrLanguageNotFound,
+			&LanguageError{Err: ErrLanguageNotFound, Language: "lt"},
+		},
+		{
+			func() error { return locale0.AddLanguages("en_gb") },
+			ErrLanguageExists,
+			&LanguageError{Err: ErrLanguageExists, Language: "en_gb"},
+		},
+		{
+			func() error { return locale0.AddLanguages("lt", "LT") },
+			ErrLanguageExists,
+			&LanguageError{Err: ErrLanguageExists, Language: "lt", Detail: "redefined in passed lang parameter"},
+		},
+		{
+			func() error { return locale0.AddLanguages("e") },
+			ErrInvalidLanguageTag,
+			&LanguageError{Err: ErrInvalidLanguageTag, Language: "e", Detail: "contains invalid language subtag 'e'"},
+		},
+		{
+			func() error { return locale0.SetFallback("LV", "en", "lv") },
+			ErrInvalidFallback,
+			&LanguageError{Err: ErrInvalidFallback, Language: "LV", Detail: "can not fall back to itself"},
+		},
+		{
+			func() error { _, err := locale0.Value("en", "key0"); return err },
+			ErrKeyNotFound,
+			&KeyError{Language: "en", Key: "key0", Searched: []string{"en"}},
+		},
+		{
+			func() error { _, err := locale0.ValuePlural("en-GB", "key0"); return err },
+			ErrKeyNotFound,
+			&KeyError{Language: "en-GB", Key: "key0", Searched: []string{"en-GB", "en"}},
+		},
+		{
+			func() error { _, err := locale0.Languages[1].Value("key0"); return err },
+			ErrKeyNotFound,
+			&KeyError{Language: "en", Key: "key0"},
+		},
+		{
+			func() error {
+				return locale0.AddTranslate(Translate{Key: "key1", Language: "lt"})
+			},
+			ErrLanguageNotFound,
+			&LanguageError{Err: ErrLanguageNotFound, Language: "lt"},
+		},
+	}
+
+	for k, v := range testCases {
+		err := v.call()
+		if err == nil {
+			t.Fatalf("expected error, index=%d", k)
+		}
+
+		if !errors.Is(err, v.target) {
+			t.Fatalf("unexpected error kind, index=%d, expected=%s, actual=%s", k, v.target, err)
+		}
+
+		switch expected := v.expected.(type) {
+		case *LanguageError:
+			actual := &LanguageError{}
+			if !errors.As(err, &actual) || !reflect.DeepEqual(expected, actual) {
+				t.Fatalf("unexpected error, index=%d, expected=%+v, actual=%+v", k, expected, err)
+			}
+		case *KeyError:
+			actual := &KeyError{}
+			if !errors.As(err, &actual) || !reflect.DeepEqual(expected, actual) {
+				t.Fatalf("unexpected error, index=%d, expected=%+v, actual=%+v", k, expected, err)
+			}
+		}
+	}
+
+	// Non-strict usage searches all languages.
+	locale0.StrictUsage = false
+
+	_, err := locale0.Value("en", "key1")
+
+	keyErr := &KeyError{}
+	if !errors.As(err, &keyErr) || len(keyErr.Searched) != 3 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestYAMLError(t *testing.T) {
+	tempDir := t.TempDir()
+
+	testCases := []struct {
+		fileName    string
+		fileContent string
+		createFile  bool
+		target      error
+		key         string
+		language    string
+	}{
+		{"missing.yaml", "", false, ErrYAMLRead, "", ""},
+		{"syntax.yaml", "key0: [\n", true, ErrYAMLSyntax, "", ""},
+		{"unsupported_0.yaml", "key0: 1\n", true, ErrYAMLUnsupportedValue, "key0", ""},
+		{"unsupported_1.yaml", "key0:\n  - en: 1\n", true, ErrYAMLUnsupportedValue, "key0", "en"},
+		{"plurals.yaml", "key0:\n  - en: [\"\", \"\", \"\"]\n", true, ErrYAMLTooManyPlurals, "key0", "en"},
+		{"language.yaml", "key0:\n  - e: \"\"\n", true, ErrInvalidLanguageTag, "key0", "e"},
+	}
+
+	for k, v := range testCases {
+		filePath := fmt.Sprintf("%s/%s", tempDir, v.fileName)
+
+		if v.createFile {
+			err := createTempFile(tempDir, v.fileName, v.fileContent)
+			if err != nil {
+				t.Fatalf("unexpected error, index=%d, error: %s", k, err)
+			}
+		}
+
+		_, err := LoadYAMLFiles("en", filePath)
+		if !errors.Is(err, v.target) {
+			t.Fatalf("unexpected error kind, index=%d, expected=%s, actual=%v", k, v.target, err)
+		}
+
+		yamlErr := &YAMLError{}
+		if !errors.As(err, &yamlErr) {
+			t.Fatalf("expected YAMLError, index=%d, actual=%v", k, err)
+		}
+
+		if yamlErr.File != filePath || yamlErr.Key != v.key || yamlErr.Language != v.language {
+			t.Fatalf("unexpected YAMLError fields, index=%d, actual=%+v", k, yamlErr)
+		}
+	}
+
+	// Underlying errors must be accessible.
+	_, err := LoadYAMLFiles("en", tempDir+"/missing.yaml")
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected os.ErrNotExist, actual=%v", err)
+	}
+}
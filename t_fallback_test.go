@@ -0,0 +1,194 @@
+package localization
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLocale_SetFallback(t *testing.T) {
+	testCases := []struct {
+		fallbacks       map[string][]string
+		defaultLang     string
+		langKey         string
+		expected        []string
+		failureExpected bool
+	}{
+		// No declared chains - initialization order.
+		{nil, "", "lv", []string{"lv", "en", "ru", "en-GB"}, false},
+		// Parent language always comes first.
+		{nil, "", "en-GB", []string{"en-GB", "en", "lv", "ru"}, false},
+		// Only default language - language followed by default.
+		{nil, "en", "lv", []string{"lv", "en"}, false},
+		{nil, "en", "en", []string{"en"}, false},
+		// Declared chains with default language at the end.
+		{map[string][]string{"lv": {"ru"}}, "en", "lv", []string{"lv", "ru", "en"}, false},
+		{map[string][]string{"en-GB": {"en"}}, "en", "en-GB", []string{"en-GB", "en"}, false},
+		{map[string][]string{"en-GB": {"lv"}}, "", "en-GB", []string{"en-GB", "en", "lv"}, false},
+		// Declared chains of parent languages follow declared chain of language.
+		{map[string][]string{"en": {"ru"}}, "", "en-GB", []string{"en-GB", "en", "ru"}, false},
+		{map[string][]string{"en-GB": {"lv"}, "en": {"ru"}}, "", "en-GB", []string{"en-GB", "en", "lv", "ru"}, false},
+		{map[string][]string{"en-GB": {"en"}, "en": {"ru"}}, "lv", "en-GB", []string{"en-GB", "en", "ru", "lv"}, false},
+		// Not initialized regional variant resolves to parent language.
+		{nil, "en", "en-US", []string{"en"}, false},
+		// Declared chain without default language.
+		{map[string][]string{"lv": {"ru"}}, "", "lv", []string{"lv", "ru"}, false},
+		{map[string][]string{"lv": {"ru"}}, "", "en", []string{"en"}, false},
+		// Chains are transitive.
+		{map[string][]string{"lv": {"ru"}, "ru": {"en-GB"}}, "en", "lv", []string{"lv", "ru", "en-GB", "en"}, false},
+		// Cycles are ignored.
+		{map[string][]string{"lv": {"ru"}, "ru": {"lv"}}, "", "lv", []string{"lv", "ru"}, false},
+		// Keywords are case-insensitive.
+		{map[string][]string{"LV": {"RU"}}, "EN", "lv", []string{"lv", "ru", "en"}, false},
+		// Error - fallback language does not exist.
+		{map[string][]string{"lv": {"lt"}}, "", "lv", nil, true},
+		// Error - language does not exist.
+		{map[string][]string{"lt": {"lv"}}, "", "lv", nil, true},
+		// Error - language falls back to itself.
+		{map[string][]string{"lv": {"lv"}}, "", "lv", nil, true},
+		// Error - valid entries are not applied if any entry is not valid.
+		{map[string][]string{"en": {"ru"}, "lv": {"ru"}, "ru": {"lt"}}, "en", "lv", nil, true},
+		// Error - default language does not exist.
+		{nil, "lt", "lv", nil, true},
+	}
+
+	for k, v := range testCases {
+		locale0, _ := NewLocale(false, "lv", "en", "ru", "en-GB")
+
+		err := locale0.ApplyFallbackConfig(FallbackConfig{Default: v.defaultLang, Fallbacks: v.fallbacks})
+		if err != nil && !v.failureExpected {
+			t.Fatalf("unexpected error, index=%d, error: %s", k, err)
+		}
+		if err == nil && v.failureExpected {
+			t.Fatalf("expected error, index=%d", k)
+		}
+
+		if v.failureExpected {
+			// Invalid config must not be applied partially.
+			if locale0.hasExplicitFallbacks() {
+				t.Fatalf("unexpected partially applied config, index=%d", k)
+			}
+
+			continue
+		}
+
+		chain, err := locale0.FallbackChain(v.langKey)
+		if err != nil {
+			t.Fatalf("unexpected error, index=%d, error: %s", k, err)
+		}
+
+		if !reflect.DeepEqual(v.expected, chain) {
+			t.Fatalf("unexpected result, index=%d, expected=%v, actual=%v",
+				k, v.expected, chain)
+		}
+	}
+}
+
+func TestLocale_ValueWithFallback(t *testing.T) {
+	locale0, _ := NewLocale(false, "en", "lv", "ru")
+	locale0.SetValueNoErr("en", "key0", "en-non-plural", "en-plural")
+	locale0.SetValueNoErr("ru", "key0", "ru-non-plural", "ru-plural")
+	locale0.SetValueNoErr("en", "key1", "en-non-plural-1", "")
+
+	// Without declared chains "en" (initialized second) would be used.
+	text, _ := locale0.Value("lv", "key0")
+	if text != "en-non-plural" {
+		t.Fatalf("unexpected result, expected=%s, actual=%s", "en-non-plural", text)
+	}
+
+	_ = locale0.SetDefaultLanguage("en")
+	_ = locale0.SetFallback("lv", "ru")
+
+	testCases := []struct {
+		langKey         string
+		textKey         string
+		expected        string
+		strictUsage     bool
+		failureExpected bool
+	}{
+		{"lv", "key0", "ru-non-plural", false, false},
+		{"lv", "key1", "en-non-plural-1", false, false},
+		{"ru", "key1", "en-non-plural-1", false, false},
+		// Error - StrictUsage disables fallbacks.
+		{"lv", "key0", "", true, true},
+	}
+
+	for k, v := range testCases {
+		locale0.StrictUsage = v.strictUsage
+
+		text, err := locale0.Value(v.langKey, v.textKey)
+		if err != nil && !v.failureExpected {
+			t.Fatalf("unexpected error, index=%d, error: %s", k, err)
+		}
+		if err == nil && v.failureExpected {
+			t.Fatalf("expected error, index=%d", k)
+		}
+
+		if v.expected != text {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s",
+				k, v.expected, text)
+		}
+	}
+}
+
+func TestLocale_LoadFallbackConfig(t *testing.T) {
+	tempDir := t.TempDir()
+
+	testCases := []struct {
+		fileName        string
+		fileContent     string
+		expected        []string
+		failureExpected bool
+	}{
+		{
+			"fallback_0.yaml",
+			"default: en\nfallbacks:\n  lv: [ru]\n",
+			[]string{"lv", "ru", "en"},
+			false,
+		},
+		{
+			"fallback_1.yaml",
+			"fallbacks:\n  lv:\n    - en\n    - ru\n",
+			[]string{"lv", "en", "ru"},
+			false,
+		},
+		{ // Error - unknown field.
+			"fallback_2.yaml",
+			"defaults: en\n",
+			nil,
+			true,
+		},
+		{ // Error - language does not exist.
+			"fallback_3.yaml",
+			"default: lt\n",
+			nil,
+			true,
+		},
+	}
+
+	for k, v := range testCases {
+		err := createTempFile(tempDir, v.fileName, v.fileContent)
+		if err != nil {
+			t.Fatalf("unexpected error, index=%d, error: %s", k, err)
+		}
+
+		locale0, _ := NewLocale(false, "en", "lv", "ru")
+
+		err = locale0.LoadFallbackConfig(tempDir + "/" + v.fileName)
+		if err != nil && !v.failureExpected {
+			t.Fatalf("unexpected error, index=%d, error: %s", k, err)
+		}
+		if err == nil && v.failureExpected {
+			t.Fatalf("expected error, index=%d", k)
+		}
+
+		if v.failureExpected {
+			continue
+		}
+
+		chain, _ := locale0.FallbackChain("lv")
+		if !reflect.DeepEqual(v.expected, chain) {
+			t.Fatalf("unexpected result, index=%d, expected=%v, actual=%v",
+				k, v.expected, chain)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package localization
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocale_ValidateFormats(t *testing.T) {
+	locale0, _ := NewLocale(false, "en", "lv", "de")
+	locale0.SetValueNoErr("en", "items", "%d items in %s", "")
+	locale0.SetValueNoErr("lv", "items", "%s: %d", "")
+	locale0.SetValueNoErr("de", "items", "%[2]s: %[1]d", "")
+	locale0.SetValueNoErr("en", "hello", "Hello, %s!", "")
+	locale0.SetValueNoErr("lv", "hello", "Sveiki!", "")
+	locale0.SetValueNoErr("de", "hello", "Hallo, %s %s!", "")
+	locale0.SetValueNoErr("en", "count", "%d item", "%d items")
+	locale0.SetValueNoErr("lv", "count", "%v lieta", "%d lietas %s")
+	locale0.SetValueNoErr("de", "count", "%d Sache", "")
+	locale0.SetValueNoErr("en", "one", "One", "%d many")
+	locale0.SetValueNoErr("lv", "one", "Viens", "%d daudz")
+	locale0.SetValueNoErr("de", "one", "Eins", "%f viele")
+	locale0.SetValueNoErr("en", "percent", "100%%", "")
+	locale0.SetValueNoErr("lv", "percent", "100%", "")
+	locale0.SetValueNoErr("lv", "only_lv", "%s", "")
+
+	issues, err := locale0.ValidateFormats("en")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{
+		"count (lv plural): arg 2 (%s) is not in source",
+		"hello (lv): arg 1 (%s) is missing",
+		"hello (de): arg 2 (%s) is not in source",
+		"items (lv): arg 1 is %s, source has %d; arg 2 is %d, source has %s",
+		"one (de plural): arg 1 is %f, source has %d",
+		"percent (lv): invalid format: missing verb at offset 3",
+	}
+
+	if len(issues) != len(expected) {
+		t.Fatalf("unexpected issue count, expected=%d, actual=%d (%v)", len(expected), len(issues), issues)
+	}
+
+	for k, v := range issues {
+		if v.String() != expected[k] {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s", k, expected[k], v.String())
+		}
+	}
+
+	_, err = locale0.ValidateFormats("ru")
+	if !errors.Is(err, ErrLanguageNotFound) {
+		t.Fatalf("expected ErrLanguageNotFound, actual=%v", err)
+	}
+}
+
+func TestLocale_ValidateFormatsSource(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "a.yml")
+
+	_ = os.WriteFile(path, []byte("hello:\n  - en: \"Hello, %s\"\n  - lv: \"Sveiki, %d\"\n"), 0o644)
+
+	locale0, err := LoadCatalog("en", path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	issues, _ := locale0.ValidateFormats("en")
+
+	expected := path + ":3: hello (lv): arg 1 is %d, source has %s"
+	if len(issues) != 1 || issues[0].String() != expected {
+		t.Fatalf("unexpected result, expected=%s, actual=%v", expected, issues)
+	}
+}
@@ -0,0 +1,69 @@
+package localization
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseFormatVerbs(t *testing.T) {
+	testCases := []struct {
+		format          string
+		expected        []FormatVerb
+		failureExpected bool
+	}{
+		{"plain 100%%", []FormatVerb{}, false},
+		{"%s has %d items", []FormatVerb{{"%s", 's', 0, 0}, {"%d", 'd', 1, 7}}, false},
+		{"%[2]s %[1]d %s", []FormatVerb{{"%[2]s", 's', 1, 0}, {"%[1]d", 'd', 0, 6}, {"%s", 's', 1, 12}}, false},
+		{"%-5.2f|%+v|%#x", []FormatVerb{{"%-5.2f", 'f', 0, 0}, {"%+v", 'v', 1, 7}, {"%#x", 'x', 2, 11}}, false},
+		{"%*d", []FormatVerb{{"%*d", '*', 0, 0}, {"%*d", 'd', 1, 0}}, false},
+		{"%[3]*.[2]*[1]f", []FormatVerb{
+			{"%[3]*.[2]*[1]f", '*', 2, 0}, {"%[3]*.[2]*[1]f", '*', 1, 0}, {"%[3]*.[2]*[1]f", 'f', 0, 0},
+		}, false},
+		{"%ā", []FormatVerb{{"%ā", 'ā', 0, 0}}, false},
+		{"100%", nil, true},
+		{"%[0]d", nil, true},
+		{"%[x]d", nil, true},
+		{"%[1d", nil, true},
+	}
+
+	for k, v := range testCases {
+		received, err := ParseFormatVerbs(v.format)
+		if v.failureExpected {
+			if !errors.Is(err, ErrFormatSyntax) {
+				t.Fatalf("expected ErrFormatSyntax, index=%d, actual=%v", k, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected error, index=%d: %s", k, err)
+		}
+
+		if !reflect.DeepEqual(received, v.expected) {
+			t.Fatalf("unexpected result, index=%d, expected=%v, actual=%v", k, v.expected, received)
+		}
+	}
+}
+
+func TestFormatArgCount(t *testing.T) {
+	testCases := []struct {
+		format   string
+		expected int
+	}{
+		{"plain", 0},
+		{"%s %d", 2},
+		{"%[3]s %[1]s", 3},
+		{"%*d", 2},
+	}
+
+	for k, v := range testCases {
+		verbs, _ := ParseFormatVerbs(v.format)
+
+		received := FormatArgCount(verbs)
+		if received != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%d, actual=%d", k, v.expected, received)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package localization
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	texttemplate "text/template"
+)
+
+func TestLocalizer_FuncMap(t *testing.T) {
+	locale0, _ := NewLocale(false, "lv", "en")
+	_ = locale0.SetDefaultLanguage("en")
+	locale0.SetValueNoErr("en", "key0", "Hello <b>", "")
+	locale0.SetValueNoErr("en", "key1", "Hello, %s!", "")
+	locale0.SetValueNoErr("en", "key2", "%d item", "%d items")
+	locale0.SetValueNoErr("en", "key3", "Hello, {name}!", "")
+	locale0.SetValueNoErr("lv", "key0", "Sveiki", "")
+
+	testCases := []struct {
+		behavior        MissingBehavior
+		localizer       Localizer
+		template        string
+		expectedHTML    string
+		expectedText    string
+		failureExpected bool
+	}{
+		{MissingAsEmpty, locale0.For("en"), `{{ t "key0" }}`, "Hello &lt;b&gt;", "Hello <b>", false},
+		{MissingAsEmpty, locale0.For("lv"), `{{ tlang }}:{{ t "key0" }}`, "lv:Sveiki", "lv:Sveiki", false},
+		{MissingAsEmpty, locale0.For("en"), `{{ tf "key1" "John" }}`, "Hello, John!", "Hello, John!", false},
+		{MissingAsEmpty, locale0.For("en"), `{{ tn "key2" 1 }}/{{ tn "key2" 3 }}`, "1 item/3 items", "1 item/3 items", false},
+		{MissingAsEmpty, locale0.For("en"), `{{ tnamed "key3" "name" "John" }}`, "Hello, John!", "Hello, John!", false},
+		{MissingAsEmpty, locale0.For("en"), `{{ tnamed "key3" "name" }}`, "", "", true},
+		{MissingAsEmpty, locale0.For("en"), `{{ tnamed "key3" 1 "John" }}`, "", "", true},
+		{MissingAsEmpty, locale0.For("en"), `[{{ t "keyX" }}]`, "[]", "[]", false},
+		{MissingAsKey, locale0.For("en"), `{{ t "keyX" }}`, "keyX", "keyX", false},
+		{MissingAsPlaceholder, locale0.For("en"), `{{ tn "keyX" 2 }}`, "[keyX]", "[keyX]", false},
+		{MissingAsError, locale0.For("en"), `{{ tf "keyX" 1 }}`, "", "", true},
+		{MissingAsError, locale0.For("en"), `{{ t "key0" }}`, "Hello &lt;b&gt;", "Hello <b>", false},
+	}
+
+	for k, v := range testCases {
+		locale0.MissingBehavior = v.behavior
+
+		htmlTmpl := htmltemplate.Must(htmltemplate.New("").Funcs(v.localizer.FuncMap()).Parse(v.template))
+		textTmpl := texttemplate.Must(texttemplate.New("").Funcs(v.localizer.FuncMap()).Parse(v.template))
+
+		htmlBuilder, textBuilder := strings.Builder{}, strings.Builder{}
+
+		htmlErr := htmlTmpl.Execute(&htmlBuilder, nil)
+		textErr := textTmpl.Execute(&textBuilder, nil)
+
+		if v.failureExpected {
+			if htmlErr == nil || textErr == nil {
+				t.Fatalf("expected failure, index=%d", k)
+			}
+
+			continue
+		}
+
+		if htmlErr != nil || textErr != nil {
+			t.Fatalf("unexpected error, index=%d, html=%v, text=%v", k, htmlErr, textErr)
+		}
+
+		if htmlBuilder.String() != v.expectedHTML {
+			t.Fatalf("unexpected HTML result, index=%d, expected=%s, actual=%s",
+				k, v.expectedHTML, htmlBuilder.String())
+		}
+
+		if textBuilder.String() != v.expectedText {
+			t.Fatalf("unexpected text result, index=%d, expected=%s, actual=%s",
+				k, v.expectedText, textBuilder.String())
+		}
+	}
+}
+
+func TestLocale_FuncMap(t *testing.T) {
+	locale0, _ := NewLocale(false, "lv", "en")
+	_ = locale0.SetDefaultLanguage("en")
+	locale0.SetValueNoErr("en", "key0", "Hello", "")
+	locale0.SetValueNoErr("lv", "key0", "Sveiki", "")
+
+	base := htmltemplate.Must(htmltemplate.New("").Funcs(locale0.FuncMap()).Parse(`{{ tlang }}:{{ t "key0" }}`))
+
+	testCases := []struct {
+		localizer *Localizer
+		expected  string
+	}{
+		{nil, "en:Hello"},
+		{&Localizer{locale0, "lv"}, "lv:Sveiki"},
+	}
+
+	for k, v := range testCases {
+		tmpl := htmltemplate.Must(base.Clone())
+		if v.localizer != nil {
+			tmpl.Funcs(v.localizer.FuncMap())
+		}
+
+		builder := strings.Builder{}
+
+		err := tmpl.Execute(&builder, nil)
+		if err != nil {
+			t.Fatalf("unexpected error, index=%d: %s", k, err)
+		}
+
+		if builder.String() != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s",
+				k, v.expected, builder.String())
+		}
+	}
+}
+
+func TestLocalizer_MissingBehavior(t *testing.T) {
+	locale0, _ := NewLocale(false, "lv")
+
+	testCases := []struct {
+		behavior MissingBehavior
+		expected string
+	}{
+		{MissingAsEmpty, ""},
+		{MissingAsKey, "keyX"},
+		{MissingAsPlaceholder, "[keyX]"},
+		{MissingAsError, ""},
+	}
+
+	for k, v := range testCases {
+		locale0.MissingBehavior = v.behavior
+		localizer := locale0.For()
+
+		received := []string{
+			localizer.T("keyX"), localizer.Tf("keyX", 1), localizer.TN("keyX", 2),
+			localizer.TNamed("keyX", nil), localizer.TNNamed("keyX", 2, nil),
+		}
+
+		for _, y := range received {
+			if y != v.expected {
+				t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s", k, v.expected, y)
+			}
+		}
+	}
+}
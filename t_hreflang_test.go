@@ -0,0 +1,106 @@
+package localization
+
+import (
+	"html/template"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRouter_Alternates(t *testing.T) {
+	locale0, _ := NewLocale(false, "lv", "en-GB", "de-CH-1996")
+	_ = locale0.SetDefaultLanguage("en-GB")
+	locale0.SetValueNoErr("lv", "route.contact", "kontakti", "")
+
+	router := NewRouter(locale0, "route.")
+
+	received := router.Alternates("https://example.com/", "/contact?a=1&b=2")
+	expected := Alternates{
+		{"lv", "https://example.com/lv/kontakti?a=1&b=2"},
+		{"en-GB", "https://example.com/en-GB/contact?a=1&b=2"},
+		{"de-CH", "https://example.com/de-CH-1996/contact?a=1&b=2"},
+		{XDefault, "https://example.com/en-GB/contact?a=1&b=2"},
+	}
+
+	if len(received) != len(expected) {
+		t.Fatalf("unexpected result, expected=%v, actual=%v", expected, received)
+	}
+
+	for k, v := range expected {
+		if received[k] != v {
+			t.Fatalf("unexpected result, index=%d, expected=%v, actual=%v", k, v, received[k])
+		}
+	}
+
+	html := string(received[:1].HTML())
+	expectedHTML := "<link rel=\"alternate\" hreflang=\"lv\" href=\"https://example.com/lv/kontakti?a=1&amp;b=2\">\n"
+
+	if html != expectedHTML {
+		t.Fatalf("unexpected HTML, expected=%s, actual=%s", expectedHTML, html)
+	}
+
+	sitemap := received[3:].SitemapXML()
+	expectedSitemap := "<xhtml:link rel=\"alternate\" hreflang=\"x-default\" href=\"https://example.com/en-GB/contact?a=1&amp;b=2\"/>\n"
+
+	if sitemap != expectedSitemap {
+		t.Fatalf("unexpected sitemap, expected=%s, actual=%s", expectedSitemap, sitemap)
+	}
+}
+
+func TestRouter_AlternatesDuplicates(t *testing.T) {
+	testCases := []struct {
+		languages []string
+		expected  []string
+	}{
+		{[]string{"de-CH-1996", "de-CH-1901", "lv"}, []string{"de-CH", "lv", XDefault}},
+		{[]string{"de-CH-1996", "lv", "de-CH"}, []string{"de-CH", "lv", XDefault}},
+		{[]string{"sl-rozaj", "sl-nedis"}, []string{"sl", XDefault}},
+	}
+
+	for k, v := range testCases {
+		locale0, _ := NewLocale(false, v.languages...)
+
+		received := NewRouter(locale0, "").Alternates("https://example.com", "/")
+		hreflangs := make([]string, len(received))
+
+		for x, alternate := range received {
+			hreflangs[x] = alternate.Hreflang
+		}
+
+		if !reflect.DeepEqual(hreflangs, v.expected) {
+			t.Fatalf("unexpected result, index=%d, expected=%v, actual=%v", k, v.expected, hreflangs)
+		}
+	}
+
+	// Exact match wins over the first language with the same hreflang.
+	locale0, _ := NewLocale(false, "de-CH-1996", "de-CH")
+
+	received := NewRouter(locale0, "").Alternates("https://example.com", "/")
+	if received[0].URL != "https://example.com/de-CH/" {
+		t.Fatalf("unexpected result, expected=%s, actual=%s", "https://example.com/de-CH/", received[0].URL)
+	}
+}
+
+func TestRouter_FuncMap(t *testing.T) {
+	locale0, _ := NewLocale(false, "lv", "en")
+	router := NewRouter(locale0, "")
+
+	tmpl := template.Must(template.New("").Funcs(router.FuncMap("https://example.com")).
+		Parse(`<head>{{ hreflang "/" }}</head>{{ range alternates "/a" }}{{ .Hreflang }};{{ end }}`))
+
+	builder := strings.Builder{}
+
+	err := tmpl.Execute(&builder, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "<head><link rel=\"alternate\" hreflang=\"lv\" href=\"https://example.com/lv/\">\n" +
+		"<link rel=\"alternate\" hreflang=\"en\" href=\"https://example.com/en/\">\n" +
+		"<link rel=\"alternate\" hreflang=\"x-default\" href=\"https://example.com/lv/\">\n" +
+		"</head>lv;en;x-default;"
+
+	if builder.String() != expected {
+		t.Fatalf("unexpected result, expected=%s, actual=%s", expected, builder.String())
+	}
+}
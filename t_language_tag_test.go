@@ -0,0 +1,159 @@
+package localization
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	testCases := []struct {
+		value           string
+		expected        Tag
+		failureExpected bool
+	}{
+		{"en", Tag{Language: "en"}, false},
+		{"EN", Tag{Language: "en"}, false},
+		{"fil", Tag{Language: "fil"}, false},
+		{"en-US", Tag{Language: "en", Region: "US"}, false},
+		{"en_us", Tag{Language: "en", Region: "US"}, false},
+		{"es-419", Tag{Language: "es", Region: "419"}, false},
+		{"sr-latn", Tag{Language: "sr", Script: "Latn"}, false},
+		{"zh-Hant-TW", Tag{Language: "zh", Script: "Hant", Region: "TW"}, false},
+		{"zh-yue-HK", Tag{Language: "zh-yue", Region: "HK"}, false},
+		{"sl-rozaj-biske", Tag{Language: "sl", Variants: []string{"rozaj", "biske"}}, false},
+		{"de-CH-1996", Tag{Language: "de", Region: "CH", Variants: []string{"1996"}}, false},
+		{"th-TH-u-nu-thai", Tag{Language: "th", Region: "TH", Extensions: []string{"u-nu-thai"}}, false},
+		{"en-a-bbb-x-a-ccc", Tag{Language: "en", Extensions: []string{"a-bbb", "x-a-ccc"}}, false},
+		{"x-whatever", Tag{Extensions: []string{"x-whatever"}}, false},
+		// Errors
+		{"", Tag{}, true},
+		{"e", Tag{}, true},
+		{"engl", Tag{}, true},
+		{"en-", Tag{}, true},
+		{"-en", Tag{}, true},
+		{"en--US", Tag{}, true},
+		{"e1", Tag{}, true},
+		{"en-US-toolongsubtag", Tag{}, true},
+		{"en-ü", Tag{}, true},
+		{"en-a", Tag{}, true},
+		{"en-x", Tag{}, true},
+		{"de-1996-1996", Tag{}, true},
+		{"*", Tag{}, true},
+	}
+
+	for k, v := range testCases {
+		tag, err := ParseTag(v.value)
+		if err != nil && !v.failureExpected {
+			t.Fatalf("unexpected error, index=%d, error: %s", k, err)
+		}
+		if err == nil && v.failureExpected {
+			t.Fatalf("expected error, index=%d, value=%s", k, v.value)
+		}
+
+		if !reflect.DeepEqual(v.expected, tag) {
+			t.Fatalf("unexpected result, index=%d, expected=%+v, actual=%+v",
+				k, v.expected, tag)
+		}
+	}
+}
+
+func TestCanonicalTag(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{"en", "en"},
+		{"pt_br", "pt-BR"},
+		{"ZH-HANT-tw", "zh-Hant-TW"},
+		{"es-419", "es-419"},
+		{"DE-ch-1996", "de-CH-1996"},
+		{"en-US-X-Private", "en-US-x-private"},
+	}
+
+	for k, v := range testCases {
+		tag, err := CanonicalTag(v.value)
+		if err != nil {
+			t.Fatalf("unexpected error, index=%d, error: %s", k, err)
+		}
+
+		if v.expected != tag {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s",
+				k, v.expected, tag)
+		}
+	}
+}
+
+func TestTag_Parent(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected []string
+	}{
+		{"en", nil},
+		{"en-US", []string{"en"}},
+		{"zh-Hant-TW", []string{"zh-Hant", "zh"}},
+		{"de-CH-1996", []string{"de-CH", "de"}},
+		{"th-TH-u-nu-thai", []string{"th-TH", "th"}},
+		{"x-whatever", nil},
+	}
+
+	for k, v := range testCases {
+		tag, err := ParseTag(v.value)
+		if err != nil {
+			t.Fatalf("unexpected error, index=%d, error: %s", k, err)
+		}
+
+		var parents []string
+
+		for parent, ok := tag.Parent(); ok; parent, ok = parent.Parent() {
+			parents = append(parents, parent.String())
+		}
+
+		if !reflect.DeepEqual(v.expected, parents) {
+			t.Fatalf("unexpected result, index=%d, expected=%v, actual=%v",
+				k, v.expected, parents)
+		}
+	}
+}
+
+func TestLocale_ValueParentLanguage(t *testing.T) {
+	locale0, _ := NewLocale(true, "en", "en-GB", "pt-BR")
+	locale0.SetValueNoErr("en", "key0", "color", "colors")
+	locale0.SetValueNoErr("en-GB", "key0", "colour", "colours")
+	locale0.SetValueNoErr("en", "key1", "elevator", "elevators")
+	locale0.SetValueNoErr("pt_br", "key0", "cor", "cores")
+
+	testCases := []struct {
+		langKey         string
+		textKey         string
+		expected        string
+		failureExpected bool
+	}{
+		{"en-GB", "key0", "colour", false},
+		{"en_gb", "key0", "colour", false},
+		// Regional variant falls back to parent, even with StrictUsage.
+		{"en-GB", "key1", "elevator", false},
+		// Not initialized regional variants resolve to parent language.
+		{"en-US", "key0", "color", false},
+		{"en-GB-oxendict", "key0", "colour", false},
+		{"pt-BR", "key0", "cor", false},
+		// Error - parent language does not exist.
+		{"pt-PT", "key0", "", true},
+		// Error - "pt-BR" is not parent of "pt".
+		{"pt", "key0", "", true},
+	}
+
+	for k, v := range testCases {
+		text, err := locale0.Value(v.langKey, v.textKey)
+		if err != nil && !v.failureExpected {
+			t.Fatalf("unexpected error, index=%d, error: %s", k, err)
+		}
+		if err == nil && v.failureExpected {
+			t.Fatalf("expected error, index=%d", k)
+		}
+
+		if v.expected != text {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s",
+				k, v.expected, text)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package localization
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func createBenchmarkLocale(strictUsage bool) *Locale {
+	locale, _ := NewLocale(strictUsage, "lv", "en", "lt", "ee", "de", "fr")
+	locale.SetValueNoErr("lv", "key0", "non-plural", "plural")
+	locale.SetValueNoErr("fr", "key1", "fr-non-plural", "fr-plural")
+
+	return locale
+}
+
+func TestLocaleIndex_find(t *testing.T) {
+	locale0, _ := NewLocale(false, "lv", "en-US", "EN")
+
+	testCases := []struct {
+		langKey  string
+		expected int
+		exist    bool
+	}{
+		{"lv", 0, true},
+		{"LV", 0, true},
+		{"en-US", 1, true},
+		{"en-us", 1, true},
+		{"EN-us", 1, true},
+		{"EN", 2, true},
+		{"en", 2, true},
+		{"ee", 0, false},
+		{"", 0, false},
+	}
+
+	for k, v := range testCases {
+		idx, exist := locale0.getIndex().find(v.langKey)
+		if exist != v.exist {
+			t.Fatalf("unexpected exist value, index=%d, expected=%v, actual=%v",
+				k, v.exist, exist)
+		}
+
+		if exist && idx != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%d, actual=%d",
+				k, v.expected, idx)
+		}
+	}
+}
+
+func TestLocaleIndex_chain(t *testing.T) {
+	locale0, _ := NewLocale(false, "en", "lv", "lt", "ee")
+	langEN, _ := locale0.GetLanguage("en")
+	langLV, _ := locale0.GetLanguage("lv")
+	langLT, _ := locale0.GetLanguage("lt")
+	langEE, _ := locale0.GetLanguage("ee")
+
+	testCases := []struct {
+		inputLang   *Language
+		strictUsage bool
+		expected    []*Language
+	}{
+		// Check if only input gets returned on StrictUsage=true
+		{langEN, true, []*Language{langEN}},
+		// Check if correctly ordered slice gets returned (langEN must be first).
+		{langEN, false, []*Language{langEN, langLV, langLT, langEE}},
+		// Check if only input gets returned on StrictUsage=true
+		{langLV, true, []*Language{langLV}},
+		// Check if correctly ordered slice gets returned (langLV must be first).
+		{langLV, false, []*Language{langLV, langEN, langLT, langEE}},
+		// Check if correctly ordered slice gets returned (langLT must be first).
+		{langLT, false, []*Language{langLT, langEN, langLV, langEE}},
+	}
+
+	index := locale0.getIndex()
+
+	for k, v := range testCases {
+		idx, _ := index.find(v.inputLang.Keyword)
+		list := index.chain(idx, v.strictUsage)
+
+		if len(list) != len(v.expected) {
+			t.Fatalf("unexpected length, index=%d, expected=%d, actual=%d",
+				k, len(v.expected), len(list))
+		}
+
+		// Validate each pointer value. Each slice element pointer must match with
+		// v.expected slice pointer values.
+		for x := range list {
+			if list[x] != v.expected[x] {
+				t.Fatalf("unexpected pointer, index=%d.%d, expected=%p, actual=%p",
+					k, x, v.expected[x], list[x])
+			}
+		}
+	}
+}
+
+func TestLocaleIndex_lazy(t *testing.T) {
+	locale0, _ := NewLocale(false, "en", "en-GB", "lv", "ru")
+	_ = locale0.SetFallback("lv", "ru")
+	_ = locale0.SetDefaultLanguage("en")
+
+	index := locale0.getIndex()
+	lazy := newLazyLocaleIndex(locale0)
+
+	for _, langKey := range []string{"en", "EN_gb", "lv", "ru", "de"} {
+		idx, exist := index.find(langKey)
+
+		lazyIdx, lazyExist := lazy.find(langKey)
+		if idx != lazyIdx || exist != lazyExist {
+			t.Fatalf("unexpected result, lang=%s, expected=%d, actual=%d", langKey, idx, lazyIdx)
+		}
+
+		if !exist {
+			continue
+		}
+
+		for _, strictUsage := range []bool{false, true} {
+			expected, actual := index.chain(idx, strictUsage), lazy.chain(idx, strictUsage)
+			if !reflect.DeepEqual(expected, actual) {
+				t.Fatalf("unexpected chain, lang=%s, strict=%v, expected=%v, actual=%v",
+					langKey, strictUsage, expected, actual)
+			}
+		}
+	}
+}
+
+func TestLocale_getIndex(t *testing.T) {
+	locale0, _ := NewLocale(false, "lv", "en")
+	index := locale0.getIndex()
+
+	// Index must be reused while languages are unchanged.
+	if index != locale0.getIndex() {
+		t.Fatalf("expected index to be reused")
+	}
+
+	// AddLanguages must invalidate index.
+	_ = locale0.AddLanguages("lt")
+	if index == locale0.getIndex() {
+		t.Fatalf("expected index to be rebuilt after AddLanguages")
+	}
+
+	if !locale0.HasLanguage("lt") {
+		t.Fatalf("expected language 'lt' to exist")
+	}
+
+	// Direct Languages modification must invalidate index.
+	locale0.Languages = []Language{{TextMap{"key0": [2]string{"ee-value", ""}}, "ee"}}
+
+	if locale0.HasLanguage("lv") {
+		t.Fatalf("unexpected language 'lv'")
+	}
+
+	value, err := locale0.Value("ee", "key0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if value != "ee-value" {
+		t.Fatalf("unexpected result, expected=%s, actual=%s", "ee-value", value)
+	}
+}
+
+func TestLocale_ValueConcurrent(t *testing.T) {
+	// Locale created without NewLocale has no stored index, lookups must not
+	// store it (run with -race).
+	locale0 := &Locale{Languages: []Language{
+		{TextMap{"key0": [2]string{"en-value", ""}}, "en"},
+		{TextMap{}, "lv"},
+	}}
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			value, err := locale0.Value("lv", "key0")
+			if err != nil || value != "en-value" {
+				t.Errorf("unexpected result, expected=%s, actual=%s, error: %v", "en-value", value, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if locale0.index != nil {
+		t.Fatalf("expected index not to be stored by lookups")
+	}
+}
+
+func TestLocale_ValueAllocations(t *testing.T) {
+	testCases := []struct {
+		strictUsage bool
+		langKey     string
+		textKey     string
+		plural      bool
+	}{
+		{true, "lv", "key0", false},
+		{true, "lv", "key0", true},
+		{false, "lv", "key0", false},
+		// Found in last fallback language.
+		{false, "lv", "key1", false},
+		{false, "en", "key1", true},
+	}
+
+	for k, v := range testCases {
+		locale0 := createBenchmarkLocale(v.strictUsage)
+
+		allocs := testing.AllocsPerRun(100, func() {
+			if v.plural {
+				_, _ = locale0.ValuePlural(v.langKey, v.textKey)
+				return
+			}
+
+			_, _ = locale0.Value(v.langKey, v.textKey)
+		})
+
+		if allocs != 0 {
+			t.Fatalf("unexpected allocations, index=%d, expected=0, actual=%v", k, allocs)
+		}
+	}
+}
+
+func BenchmarkLocale_Value(b *testing.B) {
+	locale0 := createBenchmarkLocale(true)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = locale0.Value("lv", "key0")
+	}
+}
+
+func BenchmarkLocale_ValueFallback(b *testing.B) {
+	locale0 := createBenchmarkLocale(false)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = locale0.Value("lv", "key1")
+	}
+}
+
+func BenchmarkLocale_ValueStructLiteral(b *testing.B) {
+	// Locale without stored index uses lazy index.
+	locale0 := &Locale{Languages: createBenchmarkLocale(false).Languages}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = locale0.Value("lv", "key1")
+	}
+}
+
+func BenchmarkText(b *testing.B) {
+	locale0 := createBenchmarkLocale(false)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = Text(*locale0, "lv", "key1")
+	}
+}
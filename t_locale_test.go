@@ -7,46 +7,6 @@ import (
 	"testing"
 )
 
-func TestLocale_buildPrioritizedLanguageList(t *testing.T) {
-	locale0, _ := NewLocale(false, "en", "lv", "lt", "ee")
-	langEN, _ := locale0.GetLanguage("en")
-	langLV, _ := locale0.GetLanguage("lv")
-	langLT, _ := locale0.GetLanguage("lt")
-	langEE, _ := locale0.GetLanguage("ee")
-
-	testCases := []struct {
-		inputLang   *Language
-		strictUsage bool
-		expected    []*Language
-	}{
-		// Check if only input gets returned on StrictUsage=true
-		{langEN, true, []*Language{langEN}},
-		// Check if correctly ordered slice gets returned (langEN must be first).
-		{langEN, false, []*Language{langEN, langLV, langLT, langEE}},
-		// Check if only input gets returned on StrictUsage=true
-		{langLV, true, []*Language{langLV}},
-		// Check if correctly ordered slice gets returned (langLV must be first).
-		{langLV, false, []*Language{langLV, langEN, langLT, langEE}},
-		// Check if correctly ordered slice gets returned (langLT must be first).
-		{langLT, false, []*Language{langLT, langEN, langLV, langEE}},
-	}
-
-	for k, v := range testCases {
-		locale0.StrictUsage = v.strictUsage
-
-		list := locale0.buildPrioritizedLanguageList(v.inputLang)
-
-		// Validate each pointer value. Each slice element pointer must match with
-		// v.expected slice pointer values.
-		for x := range list {
-			if list[x] != v.expected[x] {
-				t.Fatalf("unexpected pointer, index=%d.%d, expected=%p, actual=%p",
-					k, x, v.expected[k], list[k])
-			}
-		}
-	}
-}
-
 func TestLocale_AddLanguages(t *testing.T) {
 	testCases := []struct {
 		existingLangs   []string
@@ -72,6 +32,16 @@ func TestLocale_AddLanguages(t *testing.T) {
 		{[]string{"lv"}, []string{"en"}, []string{"lv", "en"}, false},
 		// No error
 		{[]string{"lv", "en"}, []string{"ee", "lt"}, []string{"lv", "en", "ee", "lt"}, false},
+		// No error - keywords get canonicalized.
+		{[]string{}, []string{"en_us", "LV", "zh-hant-tw"}, []string{"en-US", "lv", "zh-Hant-TW"}, false},
+		// Error - 'pt-BR' already initialized in language list.
+		{[]string{"pt-BR"}, []string{"pt_BR"}, []string{"pt-BR"}, true},
+		// Error - 'en-US' redefined in params
+		{[]string{}, []string{"en-US", "en_us"}, []string{}, true},
+		// Error - not valid language tags.
+		{[]string{}, []string{""}, []string{}, true},
+		{[]string{}, []string{"e"}, []string{}, true},
+		{[]string{}, []string{"en-"}, []string{}, true},
 	}
 
 	for k, v := range testCases {
@@ -94,7 +64,7 @@ func TestLocale_AddLanguages(t *testing.T) {
 		}
 
 		for x, y := range locale0.Languages {
-			if !strings.EqualFold(y.Keyword, v.expected[x]) {
+			if y.Keyword != v.expected[x] {
 				t.Fatalf("unexpected keyword, index=%d.%d, expected=%s, actual=%s",
 					k, x, v.expected[x], y.Keyword)
 			}
@@ -503,10 +473,10 @@ func TestLocale_LoadYAMLFile(t *testing.T) {
 			true,
 			false,
 			Locale{
-				[]Language{
+				Languages: []Language{
 					{TextMap{}, "lv"},
 					{TextMap{"key0": [2]string{"non_plural", ""}}, "en"},
-				}, true,
+				}, StrictUsage: true,
 			},
 		},
 		{ // No errors - file exist and content matches.
@@ -516,10 +486,10 @@ func TestLocale_LoadYAMLFile(t *testing.T) {
 			true,
 			false,
 			Locale{
-				[]Language{
+				Languages: []Language{
 					{TextMap{"key0": [2]string{"non_plural", ""}}, "lv"},
 					{TextMap{}, "en"},
-				}, true,
+				}, StrictUsage: true,
 			},
 		},
 		{
@@ -532,10 +502,10 @@ func TestLocale_LoadYAMLFile(t *testing.T) {
 			true,
 			false,
 			Locale{
-				[]Language{
+				Languages: []Language{
 					{TextMap{"key0": [2]string{"non_plural", "plural"}}, "lv"},
 					{TextMap{"key0": [2]string{"en_non_plural", "en_plural"}}, "en"},
-				}, true,
+				}, StrictUsage: true,
 			},
 		},
 		{ // No errors - file exist and content matches.
@@ -545,7 +515,7 @@ func TestLocale_LoadYAMLFile(t *testing.T) {
 			true,
 			false,
 			Locale{
-				[]Language{
+				Languages: []Language{
 					{TextMap{}, "lv"},
 					{
 						TextMap{
@@ -553,7 +523,7 @@ func TestLocale_LoadYAMLFile(t *testing.T) {
 							"key1": [2]string{"non_plural_1", ""},
 						}, "en",
 					},
-				}, true,
+				}, StrictUsage: true,
 			},
 		},
 		{ // No errors - file exist and content matches.
@@ -602,7 +572,8 @@ func TestLocale_LoadYAMLFile(t *testing.T) {
 			continue
 		}
 
-		if !reflect.DeepEqual(v.expected, *locale0) {
+		if v.expected.StrictUsage != locale0.StrictUsage ||
+			!reflect.DeepEqual(v.expected.Languages, locale0.Languages) {
 			t.Fatalf("unexpected result, index=%d, expected=%+v, actual=%+v",
 				k, v.expected, *locale0)
 		}
@@ -741,7 +712,6 @@ func TestLocale_EnabledLanguages(t *testing.T) {
 		{[]string{"lv", "en"}},
 		{[]string{"lv", "en", "ee", "lt", "en-US"}},
 		{[]string{"lv"}},
-		{[]string{""}},
 		{nil},
 	}
 
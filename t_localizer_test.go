@@ -0,0 +1,93 @@
+package localization
+
+import (
+	"testing"
+)
+
+func TestLocale_For(t *testing.T) {
+	locale0, _ := NewLocale(false, "lv", "en", "en-GB")
+
+	locale1, _ := NewLocale(false, "lv", "en")
+	_ = locale1.SetDefaultLanguage("en")
+
+	testCases := []struct {
+		locale   *Locale
+		values   []string
+		expected string
+	}{
+		{locale0, []string{"en"}, "en"},
+		{locale0, []string{"EN_gb"}, "en-GB"},
+		{locale0, []string{"en-US"}, "en"},
+		{locale0, []string{"de", "lv"}, "lv"},
+		{locale0, []string{"", "en"}, "en"},
+		{locale0, []string{"de-DE,de;q=0.9,en;q=0.8"}, "en"},
+		{locale0, []string{"de"}, "lv"},
+		{locale0, []string{}, "lv"},
+		{locale1, []string{"de"}, "en"},
+		{locale1, []string{"*"}, "en"},
+		{&Locale{}, []string{"en"}, ""},
+	}
+
+	for k, v := range testCases {
+		language := v.locale.For(v.values...).Language()
+		if language != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s",
+				k, v.expected, language)
+		}
+	}
+}
+
+func TestLocalizer(t *testing.T) {
+	locale0, _ := NewLocale(false, "lv", "en")
+	locale0.SetValueNoErr("en", "key0", "Hello", "")
+	locale0.SetValueNoErr("en", "key1", "Hello, %s!", "")
+	locale0.SetValueNoErr("en", "key2", "%d item %s", "%d items %s")
+	locale0.SetValueNoErr("en", "key3", "Hello, {name}! {unknown} {", "")
+	locale0.SetValueNoErr("en", "key4", "{count} item in {place}", "{count} items in {place}")
+	locale0.SetValueNoErr("lv", "key0", "Sveiki", "")
+
+	en := locale0.For("en")
+	lv := locale0.For("lv")
+	zero := Localizer{}
+
+	testCases := []struct {
+		received string
+		expected string
+	}{
+		{en.T("key0"), "Hello"},
+		{lv.T("key0"), "Sveiki"},
+		{lv.T("key1"), "Hello, %s!"},
+		{en.T("keyX"), ""},
+		{en.Tf("key1", "John"), "Hello, John!"},
+		{en.Tf("keyX", "John"), ""},
+		{en.TN("key2", 1, ":)"), "1 item :)"},
+		{en.TN("key2", 2, ":)"), "2 items :)"},
+		{en.TN("keyX", 2), ""},
+		{en.TNamed("key3", map[string]interface{}{"name": "John"}), "Hello, John! {unknown} {"},
+		{en.TNamed("key3", nil), "Hello, {name}! {unknown} {"},
+		{en.TNamed("keyX", nil), ""},
+		{en.TNNamed("key4", 1, map[string]interface{}{"place": "cart"}), "1 item in cart"},
+		{en.TNNamed("key4", 3, map[string]interface{}{"place": "cart"}), "3 items in cart"},
+		{en.TNNamed("key4", 3, map[string]interface{}{"count": "many"}), "many items in {place}"},
+		{zero.T("key0"), ""},
+		{zero.TN("key2", 2), ""},
+	}
+
+	for k, v := range testCases {
+		if v.received != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s",
+				k, v.expected, v.received)
+		}
+	}
+}
+
+func BenchmarkLocale_For(b *testing.B) {
+	locale0, _ := NewLocale(false, "lv", "en", "en-GB")
+	locale0.SetValueNoErr("en", "key0", "Hello", "")
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = locale0.For("en").T("key0")
+	}
+}
@@ -0,0 +1,109 @@
+package localization
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLocale_Lookup(t *testing.T) {
+	tempDir := t.TempDir()
+
+	err := createTempFile(tempDir, "lookup.yaml",
+		"key0:\n  - lv: \"lv-non-plural\"\n  - en:\n    - \"en-non-plural\"\n    - \"en-plural\"\n"+
+			"key1: \"en-non-plural-1\"\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	filePath := fmt.Sprintf("%s/%s", tempDir, "lookup.yaml")
+
+	locale0, _ := NewLocale(false, "lv", "en", "en-GB")
+	locale0.SetValueNoErr("lv", "key2", "lv-non-plural-2", "")
+
+	err = locale0.LoadYAMLFile("en", filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCases := []struct {
+		langKey         string
+		textKey         string
+		plural          bool
+		strictUsage     bool
+		expected        LookupResult
+		failureExpected bool
+	}{
+		{
+			"lv", "key0", false, false,
+			LookupResult{"lv-non-plural", "key0", "lv", "lv", false, false, Source{filePath, 2}},
+			false,
+		},
+		{
+			"en", "key0", true, false,
+			LookupResult{"en-plural", "key0", "en", "en", false, true, Source{filePath, 3}},
+			false,
+		},
+		{ // Fallback - "lv" does not contain key.
+			"lv", "key1", false, false,
+			LookupResult{"en-non-plural-1", "key1", "lv", "en", true, false, Source{filePath, 6}},
+			false,
+		},
+		{ // Fallback - "en-GB" does not contain key, parent "en" used.
+			"en-GB", "key0", false, true,
+			LookupResult{"en-non-plural", "key0", "en-GB", "en", true, false, Source{filePath, 3}},
+			false,
+		},
+		{ // Fallback - "en-US" does not exist, parent "en" used.
+			"en-US", "key0", false, true,
+			LookupResult{"en-non-plural", "key0", "en-US", "en", true, false, Source{filePath, 3}},
+			false,
+		},
+		{ // Manually added translation has no source.
+			"lv", "key2", false, false,
+			LookupResult{"lv-non-plural-2", "key2", "lv", "lv", false, false, Source{}},
+			false,
+		},
+		{ // Error - key does not exist.
+			"lv", "key1", false, true,
+			LookupResult{},
+			true,
+		},
+		{ // Error - language does not exist.
+			"lt", "key0", false, false,
+			LookupResult{},
+			true,
+		},
+	}
+
+	for k, v := range testCases {
+		locale0.StrictUsage = v.strictUsage
+
+		var result LookupResult
+
+		if v.plural {
+			result, err = locale0.LookupPlural(v.langKey, v.textKey)
+		} else {
+			result, err = locale0.Lookup(v.langKey, v.textKey)
+		}
+
+		if err != nil && !v.failureExpected {
+			t.Fatalf("unexpected error, index=%d, error: %s", k, err)
+		}
+		if err == nil && v.failureExpected {
+			t.Fatalf("expected error, index=%d", k)
+		}
+
+		if v.expected != result {
+			t.Fatalf("unexpected result, index=%d, expected=%+v, actual=%+v",
+				k, v.expected, result)
+		}
+	}
+
+	// Overriding value manually must remove source.
+	locale0.SetValueNoErr("en", "key1", "override", "")
+
+	result, _ := locale0.Lookup("en", "key1")
+	if result.Source != (Source{}) {
+		t.Fatalf("unexpected source, expected=%+v, actual=%+v", Source{}, result.Source)
+	}
+}
@@ -0,0 +1,112 @@
+package localization
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware_Handler(t *testing.T) {
+	locale0, _ := NewLocale(false, "lv", "en", "de")
+	_ = locale0.SetDefaultLanguage("en")
+	locale0.SetValueNoErr("lv", "key0", "Sveiki", "")
+	locale0.SetValueNoErr("en", "key0", "Hello", "")
+	locale0.SetValueNoErr("de", "key0", "Hallo", "")
+
+	middleware := NewMiddleware(locale0)
+
+	persisting := NewMiddleware(locale0)
+	persisting.Persist = true
+
+	headerOnly := NewMiddleware(locale0)
+	headerOnly.Sources = []LanguageSource{SourceHeader}
+
+	testCases := []struct {
+		middleware     *Middleware
+		target         string
+		cookie         string
+		acceptLanguage string
+		expected       string
+		expectedCookie string
+	}{
+		{middleware, "/lv/about", "de", "de", "Sveiki", ""},
+		{middleware, "/en-US/about", "", "", "Hello", ""},
+		{middleware, "/about?lang=de", "lv", "lv", "Hallo", ""},
+		{middleware, "/about?lang=xx", "lv", "de", "Sveiki", ""},
+		{middleware, "/about", "lv", "de", "Sveiki", ""},
+		{middleware, "/about", "", "fr-FR,de;q=0.8", "Hallo", ""},
+		{middleware, "/about", "", "fr-FR", "Hello", ""},
+		{middleware, "/", "", "", "Hello", ""},
+		{headerOnly, "/lv/about?lang=lv", "lv", "de", "Hallo", ""},
+		{persisting, "/lv/about", "", "", "Sveiki", "lv"},
+		{persisting, "/about?lang=de", "", "", "Hallo", "de"},
+		{persisting, "/about?lang=de", "de", "", "Hallo", ""},
+		{persisting, "/about", "", "lv", "Sveiki", ""},
+	}
+
+	for k, v := range testCases {
+		var received string
+
+		handler := v.middleware.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = FromContext(r.Context()).T("key0")
+		}))
+
+		request := httptest.NewRequest(http.MethodGet, v.target, nil)
+		if v.cookie != "" {
+			request.AddCookie(&http.Cookie{Name: "lang", Value: v.cookie})
+		}
+
+		if v.acceptLanguage != "" {
+			request.Header.Set("Accept-Language", v.acceptLanguage)
+		}
+
+		recorder := httptest.NewRecorder()
+		recorder.Header().Add("Vary", "Accept-Encoding, accept-language")
+
+		handler.ServeHTTP(recorder, request)
+
+		if received != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s",
+				k, v.expected, received)
+		}
+
+		lang := v.middleware.Negotiate(request).Language()
+		if recorder.Header().Get("Content-Language") != lang {
+			t.Fatalf("unexpected Content-Language, index=%d, expected=%s, actual=%s",
+				k, lang, recorder.Header().Get("Content-Language"))
+		}
+
+		if len(recorder.Header().Values("Vary")) != 1 {
+			t.Fatalf("unexpected Vary, index=%d, actual=%v", k, recorder.Header().Values("Vary"))
+		}
+
+		receivedCookie := ""
+		for _, cookie := range recorder.Result().Cookies() {
+			if cookie.Name == "lang" {
+				receivedCookie = cookie.Value
+			}
+		}
+
+		if receivedCookie != v.expectedCookie {
+			t.Fatalf("unexpected cookie, index=%d, expected=%s, actual=%s",
+				k, v.expectedCookie, receivedCookie)
+		}
+	}
+}
+
+func TestMiddleware_Vary(t *testing.T) {
+	locale0, _ := NewLocale(false, "lv")
+
+	handler := NewMiddleware(locale0).Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if recorder.Header().Get("Vary") != "Accept-Language" {
+		t.Fatalf("unexpected Vary, actual=%s", recorder.Header().Get("Vary"))
+	}
+
+	if recorder.Header().Get("Content-Language") != "lv" {
+		t.Fatalf("unexpected Content-Language, actual=%s", recorder.Header().Get("Content-Language"))
+	}
+}
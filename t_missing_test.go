@@ -0,0 +1,176 @@
+package localization
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	texttemplate "text/template"
+)
+
+func TestLocale_OnMissingKey(t *testing.T) {
+	locale0, _ := NewLocale(true, "lv", "en")
+	locale0.SetValueNoErr("lv", "key0", "non-plural", "plural")
+
+	missing := make([]MissingKey, 0)
+	locale0.OnMissingKey = func(v MissingKey) {
+		missing = append(missing, v)
+	}
+
+	_ = locale0.ValueNoErr("lv", "key0")
+	_ = locale0.ValueNoErr("en", "key0")
+	_ = locale0.ValuePluralNoErr("lv", "key1")
+	_, _ = TextPluralIntf(*locale0, "lv", "key2", 2)
+	// Not reported - language does not exist.
+	_ = locale0.ValueNoErr("lt", "key0")
+
+	expected := []MissingKey{
+		{Language: "en", Key: "key0", Plural: false},
+		{Language: "lv", Key: "key1", Plural: true},
+		{Language: "lv", Key: "key2", Plural: true},
+	}
+
+	if len(expected) != len(missing) {
+		t.Fatalf("unexpected missing key count, expected=%d, actual=%d", len(expected), len(missing))
+	}
+
+	for k, v := range missing {
+		if !strings.Contains(v.Caller, "t_missing_test.go:") {
+			t.Fatalf("unexpected caller, index=%d, caller=%s", k, v.Caller)
+		}
+
+		v.Caller = ""
+
+		if expected[k] != v {
+			t.Fatalf("unexpected result, index=%d, expected=%+v, actual=%+v", k, expected[k], v)
+		}
+	}
+}
+
+func TestLocale_OnMissingKeyTemplate(t *testing.T) {
+	locale0, _ := NewLocale(true, "lv", "en")
+
+	callers := make([]string, 0)
+	locale0.OnMissingKey = func(v MissingKey) {
+		callers = append(callers, v.Caller)
+	}
+
+	funcs := locale0.For("lv").FuncMap()
+	textTmpl := texttemplate.Must(texttemplate.New("").Funcs(funcs).Parse(`{{ t "key0" }}`))
+	htmlTmpl := htmltemplate.Must(htmltemplate.New("").Funcs(funcs).Parse(`{{ if true }}{{ tn "key1" 2 }}{{ end }}`))
+
+	_, file, line, _ := runtime.Caller(0)
+	_ = textTmpl.Execute(&bytes.Buffer{}, nil)
+	_ = htmlTmpl.Execute(&bytes.Buffer{}, nil)
+
+	expected := []string{fmt.Sprintf("%s:%d", file, line+1), fmt.Sprintf("%s:%d", file, line+2)}
+
+	if !reflect.DeepEqual(expected, callers) {
+		t.Fatalf("unexpected result, expected=%v, actual=%v", expected, callers)
+	}
+}
+
+func TestMissingKeyCollector(t *testing.T) {
+	locale0, _ := NewLocale(true, "lv", "en")
+	locale0.SetValueNoErr("en", "key0", "non-plural", "plural")
+
+	collector := NewMissingKeyCollector()
+	locale0.OnMissingKey = collector.Handle
+
+	for i := 0; i < 3; i++ {
+		_ = locale0.ValueNoErr("lv", "key0")
+	}
+
+	_ = locale0.ValuePluralNoErr("LV", "key0")
+	_ = locale0.ValuePluralNoErr("en", "key1")
+	_ = locale0.ValueNoErr("lv", "key1")
+
+	entries := collector.Entries()
+
+	for k := range entries {
+		if len(entries[k].Callers) == 0 {
+			t.Fatalf("expected callers, index=%d", k)
+		}
+
+		entries[k].Callers = nil
+	}
+
+	expected := []MissingKeyEntry{
+		{Language: "en", Key: "key1", Plural: true, Count: 1},
+		{Language: "lv", Key: "key0", Plural: true, Count: 4},
+		{Language: "lv", Key: "key1", Plural: false, Count: 1},
+	}
+
+	if !reflect.DeepEqual(expected, entries) {
+		t.Fatalf("unexpected result, expected=%+v, actual=%+v", expected, entries)
+	}
+
+	report := bytes.Buffer{}
+
+	err := collector.WriteReport(&report)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if lines := strings.Count(report.String(), "\n"); lines != 3 {
+		t.Fatalf("unexpected report line count, expected=3, actual=%d", lines)
+	}
+
+	collector.Reset()
+
+	if len(collector.Entries()) != 0 {
+		t.Fatalf("expected no entries after reset")
+	}
+}
+
+func TestMissingKeyCollector_WriteYAML(t *testing.T) {
+	collector := NewMissingKeyCollector()
+	collector.Handle(MissingKey{Language: "lv", Key: "key1"})
+	collector.Handle(MissingKey{Language: "en", Key: "key0", Plural: true})
+	collector.Handle(MissingKey{Language: "lv", Key: "key0"})
+
+	stubs := bytes.Buffer{}
+
+	err := collector.WriteYAML(&stubs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Stubs must be loadable as YAML translate file.
+	content := newYAMLContent("en")
+
+	err = content.unmarshal(stubs.Bytes())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	translates, err := content.parse()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []Translate{
+		{Key: "key0", Language: "en", Value: "", Plural: "", Line: 2},
+		{Key: "key0", Language: "lv", Value: "", Plural: "", Line: 5},
+		{Key: "key1", Language: "lv", Value: "", Plural: "", Line: 7},
+	}
+
+	if !reflect.DeepEqual(expected, translates) {
+		t.Fatalf("unexpected result, expected=%+v, actual=%+v\n%s", expected, translates, stubs.String())
+	}
+
+	if !strings.Contains(stubs.String(), "- en:\n      - \"\"\n      - \"\"\n") {
+		t.Fatalf("expected plural stub, actual:\n%s", stubs.String())
+	}
+
+	// Empty collector writes nothing.
+	stubs.Reset()
+
+	_ = NewMissingKeyCollector().WriteYAML(&stubs)
+	if stubs.Len() != 0 {
+		t.Fatalf("unexpected output: %s", stubs.String())
+	}
+}
@@ -0,0 +1,211 @@
+package localization
+
+import (
+	"errors"
+	htmltemplate "html/template"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRichText(t *testing.T) {
+	testCases := []struct {
+		value           string
+		expected        []Segment
+		failureExpected bool
+	}{
+		{"", nil, false},
+		{"plain", []Segment{{Text: "plain"}}, false},
+		{"Read <link>terms</link> and <b>privacy policy</b>", []Segment{
+			{Text: "Read "},
+			{Tag: "link", Children: []Segment{{Text: "terms"}}},
+			{Text: " and "},
+			{Tag: "b", Children: []Segment{{Text: "privacy policy"}}},
+		}, false},
+		{"<b>bold <i>both</i></b>", []Segment{
+			{Tag: "b", Children: []Segment{{Text: "bold "}, {Tag: "i", Children: []Segment{{Text: "both"}}}}},
+		}, false},
+		{"Line<br/>break", []Segment{{Text: "Line"}, {Tag: "br"}, {Text: "break"}}, false},
+		{"<x></x>", []Segment{{Tag: "x"}}, false},
+		{"a < b, <3, <1x>, < b>", []Segment{{Text: "a < b, <3, <1x>, < b>"}}, false},
+		{"<b>unclosed", nil, true},
+		{"stray</b>", nil, true},
+		{"<b><i>x</b></i>", nil, true},
+	}
+
+	for k, v := range testCases {
+		received, err := ParseRichText(v.value)
+		if v.failureExpected {
+			if !errors.Is(err, ErrRichTextSyntax) {
+				t.Fatalf("expected ErrRichTextSyntax, index=%d, actual=%v", k, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected error, index=%d: %s", k, err)
+		}
+
+		if !reflect.DeepEqual(received, v.expected) {
+			t.Fatalf("unexpected result, index=%d, expected=%v, actual=%v", k, v.expected, received)
+		}
+	}
+}
+
+func TestRichRenderer_Render(t *testing.T) {
+	segments, err := ParseRichText(`Read <link>"terms" & <b>rules</b></link><x>!</x>`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	link, _ := HTMLElement("a", "href", `/terms?a=1&b="2"`)
+	bold, _ := HTMLElement("b")
+
+	testCases := []struct {
+		renderer RichRenderer
+		expected string
+	}{
+		{RichRenderer{}, `Read "terms" & rules!`},
+		{HTMLRenderer(map[string]RichTag{"link": link, "b": bold}),
+			`Read <a href="/terms?a=1&amp;b=&#34;2&#34;">&#34;terms&#34; &amp; <b>rules</b></a>!`},
+		{ANSIRenderer(map[string]RichTag{"link": ANSILink("https://x.y")}),
+			"Read \x1b]8;;https://x.y\x1b\\\"terms\" & \x1b[1mrules\x1b[22m\x1b]8;;\x1b\\!"},
+	}
+
+	for k, v := range testCases {
+		received := v.renderer.Render(segments)
+		if received != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s", k, v.expected, received)
+		}
+	}
+
+	if PlainText(segments) != testCases[0].expected {
+		t.Fatalf("unexpected plain text, actual=%s", PlainText(segments))
+	}
+}
+
+func TestLocalizer_RichHTML(t *testing.T) {
+	locale0, _ := NewLocale(false, "en")
+	locale0.SetValueNoErr("en", "terms", "Hi {name}, read <link>terms</link> and <b>privacy policy</b>", "")
+	locale0.SetValueNoErr("en", "broken", "<b>{name}", "")
+
+	en := locale0.For("en")
+	link, _ := HTMLElement("a", "href", "/terms")
+	tags := map[string]RichTag{"link": link}
+	args := map[string]interface{}{"name": "<John>"}
+
+	testCases := []struct {
+		received htmltemplate.HTML
+		expected htmltemplate.HTML
+	}{
+		{en.RichHTML("terms", tags, args), `Hi &lt;John&gt;, read <a href="/terms">terms</a> and privacy policy`},
+		{en.RichHTML("broken", tags, args), `&lt;b&gt;&lt;John&gt;`},
+		{en.RichHTML("missing", tags, args), ``},
+	}
+
+	for k, v := range testCases {
+		if v.received != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s", k, v.expected, v.received)
+		}
+	}
+
+	segments, err := en.Rich("terms")
+	if err != nil || len(segments) != 4 {
+		t.Fatalf("unexpected result, segments=%v err=%v", segments, err)
+	}
+
+	tmpl := htmltemplate.Must(htmltemplate.New("").Funcs(en.FuncMap()).Parse(
+		`{{ trich "terms" (richtags "link" (richel "a" "href" .URL) "b" (richel "strong")) "name" .Name }}`))
+
+	builder := strings.Builder{}
+
+	err = tmpl.Execute(&builder, map[string]string{"URL": "/t?x=<1>", "Name": "J&J"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `Hi J&amp;J, read <a href="/t?x=&lt;1&gt;">terms</a> and <strong>privacy policy</strong>`
+	if builder.String() != expected {
+		t.Fatalf("unexpected result, expected=%s, actual=%s", expected, builder.String())
+	}
+}
+
+func TestHTMLElement(t *testing.T) {
+	testCases := []struct {
+		name       string
+		attributes []string
+		expected   string
+		isError    bool
+	}{
+		{"a", []string{"href", "https://x.y/terms", "data-id", "1"}, `<a href="https://x.y/terms" data-id="1">c</a>`, false},
+		{"SPAN", []string{"Class", "x"}, `<span class="x">c</span>`, false},
+		{"a", []string{"href", "mailto:x@y.z"}, `<a href="mailto:x@y.z">c</a>`, false},
+		// Invalid element names.
+		{"", nil, "", true},
+		{"a b", nil, "", true},
+		{`a href="x"`, nil, "", true},
+		{"script", nil, "", true},
+		// Invalid attribute names.
+		{"a", []string{`href="x" x`, "1"}, "", true},
+		{"a", []string{"title>", "1"}, "", true},
+		// Event handlers.
+		{"a", []string{"onclick", "alert(1)"}, "", true},
+		{"a", []string{"OnMouseOver", "alert(1)"}, "", true},
+		// URL schemes.
+		{"a", []string{"href", "javascript:alert(1)"}, "", true},
+		{"img", []string{"SRC", " JavaScript:alert(1)"}, "", true},
+		{"a", []string{"href", "data:text/html,x"}, "", true},
+		// Attributes must be pairs.
+		{"a", []string{"href"}, "", true},
+	}
+
+	for k, v := range testCases {
+		tag, err := HTMLElement(v.name, v.attributes...)
+		if v.isError {
+			if !errors.Is(err, ErrHTMLElement) {
+				t.Fatalf("expected ErrHTMLElement, index=%d, actual=%v", k, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected error, index=%d: %s", k, err)
+		}
+
+		if tag("c") != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s", k, v.expected, tag("c"))
+		}
+	}
+}
+
+func TestLocalizer_FuncMapRichErrors(t *testing.T) {
+	locale0, _ := NewLocale(false, "en")
+	locale0.SetValueNoErr("en", "terms", "Read <link>terms</link>", "")
+	locale0.SetValueNoErr("en", "broken", "<b>terms", "")
+	locale0.HTMLPolicy = &HTMLPolicy{URLSchemes: []string{"https"}}
+
+	testCases := []struct {
+		behavior MissingBehavior
+		template string
+		isError  bool
+	}{
+		{MissingAsEmpty, `{{ trich "broken" (richtags) }}`, false},
+		{MissingAsError, `{{ trich "broken" (richtags) }}`, true},
+		{MissingAsEmpty, `{{ trich "terms" (richtags "link" (richel "a" "onclick" "x")) }}`, true},
+		{MissingAsEmpty, `{{ trich "terms" (richtags "link" (richel "a" "href" "mailto:x@y.z")) }}`, true},
+		{MissingAsEmpty, `{{ trich "terms" (richtags "link" (richel "a" "href" "https://x.y")) }}`, false},
+	}
+
+	for k, v := range testCases {
+		locale0.MissingBehavior = v.behavior
+
+		tmpl := htmltemplate.Must(htmltemplate.New("").Funcs(locale0.For("en").FuncMap()).Parse(v.template))
+
+		err := tmpl.Execute(&strings.Builder{}, nil)
+		if (err != nil) != v.isError {
+			t.Fatalf("unexpected error, index=%d, expected error=%v, actual=%v", k, v.isError, err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package localization
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestRouter() *Router {
+	locale0, _ := NewLocale(false, "lv", "en")
+	_ = locale0.SetDefaultLanguage("lv")
+	locale0.SetValueNoErr("lv", "route.contact", "kontakti", "")
+	locale0.SetValueNoErr("en", "route.contact", "contact", "")
+	locale0.SetValueNoErr("lv", "route.about", "par-mums", "")
+
+	router := NewRouter(locale0, "route.")
+	router.SkipPrefixes = []string{"/static/"}
+
+	return router
+}
+
+func TestRouter_Handler(t *testing.T) {
+	router := newTestRouter()
+
+	testCases := []struct {
+		target         string
+		acceptLanguage string
+		expectedCode   int
+		expectedPath   string // Wrapped handler path or redirect location.
+		expectedLang   string
+	}{
+		{"/lv/kontakti", "", http.StatusOK, "/contact", "lv"},
+		{"/en/contact?x=1", "", http.StatusOK, "/contact", "en"},
+		{"/en/par-mums", "", http.StatusOK, "/par-mums", "en"},
+		{"/lv/par-mums/team/", "", http.StatusOK, "/about/team/", "lv"},
+		{"/lv", "", http.StatusOK, "/", "lv"},
+		{"/lv/", "", http.StatusOK, "/", "lv"},
+		{"/static/app.css", "", http.StatusOK, "/static/app.css", ""},
+		{"/contact", "", http.StatusFound, "/lv/kontakti", ""},
+		{"/contact?x=1", "en-US,en;q=0.5", http.StatusFound, "/en/contact?x=1", ""},
+		{"/contact?lang=en", "", http.StatusFound, "/en/contact?lang=en", ""},
+		{"/", "", http.StatusFound, "/lv/", ""},
+		{"/EN/contact", "", http.StatusFound, "/en/contact", ""},
+		{"/en-GB/contact", "", http.StatusFound, "/en/contact", ""},
+		{"/fr/contact", "", http.StatusFound, "/lv/fr/kontakti", ""},
+	}
+
+	for k, v := range testCases {
+		receivedPath, receivedLang := "", ""
+
+		handler := router.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedPath = r.URL.Path
+			if _, ok := r.Context().Value(localizerContextKey{}).(Localizer); ok {
+				receivedLang = FromContext(r.Context()).Language()
+			}
+		}))
+
+		request := httptest.NewRequest(http.MethodGet, v.target, nil)
+		if v.acceptLanguage != "" {
+			request.Header.Set("Accept-Language", v.acceptLanguage)
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		if recorder.Code != v.expectedCode {
+			t.Fatalf("unexpected status code, index=%d, expected=%d, actual=%d",
+				k, v.expectedCode, recorder.Code)
+		}
+
+		if recorder.Code != http.StatusOK {
+			receivedPath = recorder.Header().Get("Location")
+		}
+
+		if receivedPath != v.expectedPath {
+			t.Fatalf("unexpected path, index=%d, expected=%s, actual=%s",
+				k, v.expectedPath, receivedPath)
+		}
+
+		if receivedLang != v.expectedLang {
+			t.Fatalf("unexpected language, index=%d, expected=%s, actual=%s",
+				k, v.expectedLang, receivedLang)
+		}
+	}
+}
+
+func TestRouter_URL(t *testing.T) {
+	router := newTestRouter()
+
+	testCases := []struct {
+		lang     string
+		path     string
+		expected string
+	}{
+		{"lv", "/contact", "/lv/kontakti"},
+		{"en", "/contact", "/en/contact"},
+		{"en-US", "/contact?x=1", "/en/contact?x=1"},
+		{"en", "/about/team", "/en/about/team"},
+		{"lv", "/about/team", "/lv/par-mums/team"},
+		{"lv", "", "/lv/"},
+	}
+
+	for k, v := range testCases {
+		received := router.URL(v.lang, v.path)
+		if received != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s",
+				k, v.expected, received)
+		}
+	}
+}
+
+func TestRouter_CurrentURL(t *testing.T) {
+	router := newTestRouter()
+
+	var received string
+
+	handler := router.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = router.CurrentURL(r, "en")
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/lv/kontakti?x=1", nil))
+
+	if received != "/en/contact?x=1" {
+		t.Fatalf("unexpected result in handler, actual=%s", received)
+	}
+
+	received = router.CurrentURL(httptest.NewRequest(http.MethodGet, "/en/contact", nil), "lv")
+	if received != "/lv/kontakti" {
+		t.Fatalf("unexpected result, actual=%s", received)
+	}
+}
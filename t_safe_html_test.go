@@ -0,0 +1,110 @@
+package localization
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+)
+
+func TestHTMLPolicy_Sanitize(t *testing.T) {
+	policy := DefaultHTMLPolicy()
+
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{"Read <strong>terms</strong>", "Read <strong>terms</strong>"},
+		{`<a href="/terms" onclick="alert(1)" title="T">terms</a>`, `<a href="/terms" title="T">terms</a>`},
+		{`<a href="https://example.com?a=1&amp;b=2">x</a>`, `<a href="https://example.com?a=1&amp;b=2">x</a>`},
+		{`<a href="javascript:alert(1)">x</a>`, `<a>x</a>`},
+		{`<a href=" JaVaScRiPt:alert(1)">x</a>`, `<a>x</a>`},
+		{`<a href="mailto:a@b.c">x</a>`, `<a href="mailto:a@b.c">x</a>`},
+		{`<script>alert("x")</script>text`, "text"},
+		{`<style>b{}</style><div>text</div>`, "text"},
+		{`<img src="x" onerror="alert(1)">text<br/>`, "text<br>"},
+		{`unclosed <b>bold`, "unclosed <b>bold</b>"},
+		{`<a href="https://evil.example">click`, `<a href="https://evil.example">click</a>`},
+		{`<b/>rest`, "<b></b>rest"},
+		{`<a href="x"/>rest`, `<a href="x"></a>rest`},
+		{`<b><i>x</b></i>`, "<b><i>x</i></b>"},
+		{`<b><i><u>x</i>y</b>z</u>`, "<b><i><u>x</u></i>y</b>z"},
+		{`<b>a<b>b</b>c</b>`, "<b>a<b>b</b>c</b>"},
+		{`stray </b></i> end`, "stray  end"},
+		{`<!-- comment -->a &lt; b &amp; "c"`, "a &lt; b &amp; &#34;c&#34;"},
+		{`<SPAN CLASS="x" style="color:red">y</SPAN>`, `<span class="x">y</span>`},
+	}
+
+	for k, v := range testCases {
+		received := policy.Sanitize(v.value)
+		if received != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s",
+				k, v.expected, received)
+		}
+	}
+}
+
+func TestLocalizer_HTML(t *testing.T) {
+	locale0, _ := NewLocale(false, "en")
+	locale0.SetValueNoErr("en", "terms_html", `Accept <a href="/terms">terms</a>, %s!`, "")
+	locale0.SetValueNoErr("en", "bad_html", `<b onclick="x()">Hi</b><script>x()</script>`, "")
+	locale0.SetValueNoErr("en", "plain", "Use <b> tag, %s", "")
+	locale0.SetValueNoErr("en", "items_html", "<b>%d</b> item %s", "<b>%d</b> items %s")
+	locale0.SetValueNoErr("en", "greeting_html", "<i>Hello, {name}!</i>", "")
+
+	en := locale0.For("en")
+
+	testCases := []struct {
+		received htmltemplate.HTML
+		expected htmltemplate.HTML
+	}{
+		{en.HTML("terms_html", "<John>"), `Accept <a href="/terms">terms</a>, &lt;John&gt;!`},
+		{en.HTML("bad_html"), `<b>Hi</b>`},
+		{en.HTML("plain", "<i>x</i>"), `Use &lt;b&gt; tag, &lt;i&gt;x&lt;/i&gt;`},
+		{en.HTMLNamed("greeting_html", map[string]interface{}{"name": `<script>x</script>`}),
+			`<i>Hello, &lt;script&gt;x&lt;/script&gt;!</i>`},
+		{en.HTMLNamed("plain", map[string]interface{}{"x": "<b>"}), `Use &lt;b&gt; tag, %s`},
+		{en.HTML("missing_html"), ""},
+	}
+
+	for k, v := range testCases {
+		if v.received != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s",
+				k, v.expected, v.received)
+		}
+	}
+
+	locale0.HTMLPolicy = &HTMLPolicy{Elements: map[string][]string{"a": {"href"}}}
+
+	received := en.HTML("terms_html", "x")
+	if received != `Accept <a href="/terms">terms</a>, x!` {
+		t.Fatalf("unexpected result with custom policy, actual=%s", received)
+	}
+
+	received = en.HTML("bad_html")
+	if received != `Hi` {
+		t.Fatalf("unexpected result with custom policy, actual=%s", received)
+	}
+}
+
+func TestLocalizer_FuncMapHTML(t *testing.T) {
+	locale0, _ := NewLocale(false, "en")
+	locale0.SetValueNoErr("en", "terms_html", `<a href="/terms">%s</a>`, "")
+	locale0.SetValueNoErr("en", "items_html", "<b>%d</b> item", "<b>%d</b> items")
+	locale0.SetValueNoErr("en", "greeting_html", "<i>{name}</i>", "")
+	locale0.SetValueNoErr("en", "plain", "<b>plain</b>", "")
+
+	tmpl := htmltemplate.Must(htmltemplate.New("").Funcs(locale0.For("en").FuncMap()).Parse(
+		`{{ tf "terms_html" .Name }}|{{ tn "items_html" 2 }}|{{ tnamed "greeting_html" "name" .Name }}|{{ t "plain" }}`))
+
+	builder := strings.Builder{}
+
+	err := tmpl.Execute(&builder, map[string]string{"Name": "<x>"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `<a href="/terms">&lt;x&gt;</a>|<b>2</b> items|<i>&lt;x&gt;</i>|&lt;b&gt;plain&lt;/b&gt;`
+	if builder.String() != expected {
+		t.Fatalf("unexpected result, expected=%s, actual=%s", expected, builder.String())
+	}
+}
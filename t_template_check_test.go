@@ -0,0 +1,102 @@
+package localization
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTemplateKeys(t *testing.T) {
+	text := `{{ define "head" }}<title>{{ t "title" }}</title>{{ end }}
+<p>{{ Text $.Locale $.Lang "hello" }}</p>
+{{ if .X }}{{ tn "items" .Count }}{{ else }}{{ printf "%s" (tf "nested" 1) }}{{ end }}
+{{ t .Dynamic }}{{ "piped" | t }}{{ range .Y }}{{ TextPluralIntf $.Locale $.Lang "plural" 1 }}{{ end }}
+{{ Text $.Locale $.Lang }}{{ unknown "x" }}`
+
+	refs, err := TemplateKeys("page.html", text, "", "", DefaultTemplateKeyFuncs())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []KeyRef{
+		{"title", "t", "page.html", 1, 32},
+		{"hello", "Text", "page.html", 2, 28},
+		{"items", "tn", "page.html", 3, 18},
+		{"nested", "tf", "page.html", 3, 64},
+		{"plural", "TextPluralIntf", "page.html", 4, 82},
+	}
+
+	if !reflect.DeepEqual(refs, expected) {
+		t.Fatalf("unexpected result, expected=%v, actual=%v", expected, refs)
+	}
+
+	refs, err = TemplateKeys("page.html", `[[ t "x" ]]{{ t "y" }}`, "[[", "]]", DefaultTemplateKeyFuncs())
+	if err != nil || len(refs) != 1 || refs[0].Key != "x" {
+		t.Fatalf("unexpected result with custom delimiters, refs=%v, err=%v", refs, err)
+	}
+
+	_, err = TemplateKeys("page.html", `{{ t "x" `, "", "", DefaultTemplateKeyFuncs())
+	if err == nil {
+		t.Fatalf("expected parse failure")
+	}
+}
+
+func TestTemplateChecker_Check(t *testing.T) {
+	locale0, _ := NewLocale(false, "en", "en-GB", "lv")
+	locale0.SetValueNoErr("en", "hello", "Hello", "")
+	locale0.SetValueNoErr("lv", "hello", "Sveiki", "")
+	locale0.SetValueNoErr("en", "title", "Title", "")
+	locale0.SetValueNoErr("en-GB", "colour", "Colour", "")
+
+	checker := NewTemplateChecker(locale0)
+
+	issues, err := checker.Check("page.html", `{{ t "hello" }}{{ t "title" }}
+{{ t "colour" }}{{ Text .L .Lang "none" }}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{
+		`page.html:1:21: t "title" missing for languages: lv`,
+		`page.html:2:6: t "colour" missing for languages: en, lv`,
+		`page.html:2:34: Text "none" missing for languages: en, en-GB, lv`,
+	}
+
+	if len(issues) != len(expected) {
+		t.Fatalf("unexpected issue count, expected=%d, actual=%d (%v)", len(expected), len(issues), issues)
+	}
+
+	for k, v := range issues {
+		if v.String() != expected[k] {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s", k, expected[k], v.String())
+		}
+	}
+}
+
+func TestTemplateChecker_CheckGlob(t *testing.T) {
+	tempDir := t.TempDir()
+
+	_ = os.WriteFile(filepath.Join(tempDir, "a.html"), []byte(`{{ t "hello" }}{{ t "missing" }}`), 0o644)
+	_ = os.WriteFile(filepath.Join(tempDir, "b.html"), []byte(`{{ tf "other" 1 }}`), 0o644)
+	_ = os.Mkdir(filepath.Join(tempDir, "dir.html"), 0o755)
+
+	locale0, _ := NewLocale(false, "en")
+	locale0.SetValueNoErr("en", "hello", "Hello", "")
+
+	issues, err := NewTemplateChecker(locale0).CheckGlob(filepath.Join(tempDir, "*.html"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(issues) != 2 || issues[0].Key != "missing" || issues[1].Key != "other" {
+		t.Fatalf("unexpected result, actual=%v", issues)
+	}
+
+	_ = os.WriteFile(filepath.Join(tempDir, "c.html"), []byte(`{{ end }}`), 0o644)
+
+	_, err = NewTemplateChecker(locale0).CheckGlob(filepath.Join(tempDir, "*.html"))
+	if err == nil {
+		t.Fatalf("expected parse failure")
+	}
+}
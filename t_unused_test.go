@@ -0,0 +1,83 @@
+package localization
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLocale_UnusedKeys(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "a.yml")
+
+	_ = os.WriteFile(path, []byte(`
+used: "Used"
+dead:
+  - en: "Dead"
+  - lv: "Miris"
+error.not_found: "Not found"
+`), 0o644)
+
+	locale0, _ := NewLocale(false, "en", "lv")
+	_ = locale0.GlobalYAMLLoad("en", filepath.Join(tempDir, "*.yml"))
+	locale0.SetValueNoErr("lv", "manual", "Manuāls", "")
+
+	unused, err := locale0.UnusedKeys([]string{"used", "other"}, "error.*")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []UnusedKey{
+		{Key: "dead", Languages: []string{"en", "lv"}, Sources: []Source{{File: path, Line: 4}}},
+		{Key: "manual", Languages: []string{"lv"}},
+	}
+
+	if !reflect.DeepEqual(unused, expected) {
+		t.Fatalf("unexpected result, expected=%v, actual=%v", expected, unused)
+	}
+
+	_, err = locale0.UnusedKeys(nil, "[")
+	if err == nil {
+		t.Fatalf("expected failure for invalid pattern")
+	}
+}
+
+func TestRemoveYAMLKeys(t *testing.T) {
+	testCases := []struct {
+		content       string
+		keys          []string
+		expected      string
+		expectedCount int
+	}{
+		{"key0: a\n\n# TODO main.go:3\nkey1:\n  - en: \"\"\n  - lv: \"\"\n\n# Other\nkey2: c\n", []string{"key1"},
+			"key0: a\n\n# Other\nkey2: c\n", 1},
+		{"# Header\n\nkey0: a\nkey1: b\nkey2: c", []string{"key2", "key0", "none"}, "# Header\n\nkey1: b", 2},
+		{"key0:\n  - en: a # x\n\n  # inner\nkey1: b\n", []string{"key0"}, "key1: b\n", 1},
+		{"key0: a\n", []string{"none"}, "key0: a\n", 0},
+		{"# Header\nkey0: a\nkey1: b", []string{"key0"}, "# Header\nkey1: b", 1},
+		{"# Section\n\n# Key 1\nkey1: b\n# Key 2\nkey2: c\n", []string{"key1"}, "# Section\n\n# Key 2\nkey2: c\n", 1},
+		{"key0: a\n# Foot 0\n\n# Key 1\nkey1: b\n", []string{"key1"}, "key0: a\n# Foot 0\n", 1},
+	}
+
+	for k, v := range testCases {
+		path := filepath.Join(t.TempDir(), "a.yml")
+		_ = os.WriteFile(path, []byte(v.content), 0o644)
+
+		count, err := RemoveYAMLKeys(path, v.keys...)
+		if err != nil {
+			t.Fatalf("unexpected error, index=%d: %s", k, err)
+		}
+
+		received, _ := os.ReadFile(path)
+		if string(received) != v.expected || count != v.expectedCount {
+			t.Fatalf("unexpected result, index=%d, count=%d, expected=%s, actual=%s",
+				k, count, v.expected, received)
+		}
+	}
+
+	_, err := RemoveYAMLKeys(filepath.Join(t.TempDir(), "none.yml"), "key0")
+	if err == nil {
+		t.Fatalf("expected failure for missing file")
+	}
+}
@@ -136,7 +136,12 @@ func TestYAMLContent_unmarshal(t *testing.T) {
 			t.Fatalf("expected error, index=%d", k)
 		}
 
-		mapAsString := fmt.Sprintf("%v", content.Data)
+		data := map[string]interface{}{}
+		if content.Data != nil {
+			_ = content.Data.Decode(&data)
+		}
+
+		mapAsString := fmt.Sprintf("%v", data)
 
 		if !strings.EqualFold(mapAsString, v.expectedMapAsString) {
 			t.Fatalf("unexpected Data content, index=%d, expected=%s, actual=%s",
@@ -163,7 +168,7 @@ func TestYAMLContent_parse(t *testing.T) {
 			true,
 			false,
 			[]Translate{
-				{Key: "key0", Language: "en", Value: "text", Plural: ""},
+				{Key: "key0", Language: "en", Value: "text", Plural: "", Line: 1},
 			},
 		},
 		{ // No errors - file exist and content matches.
@@ -173,8 +178,8 @@ func TestYAMLContent_parse(t *testing.T) {
 			true,
 			false,
 			[]Translate{
-				{Key: "key0", Language: "lv", Value: "text", Plural: ""},
-				{Key: "key1", Language: "lv", Value: "text_1", Plural: ""},
+				{Key: "key0", Language: "lv", Value: "text", Plural: "", Line: 1},
+				{Key: "key1", Language: "lv", Value: "text_1", Plural: "", Line: 2},
 			},
 		},
 		{ // No errors - file exist and content matches.
@@ -184,7 +189,7 @@ func TestYAMLContent_parse(t *testing.T) {
 			true,
 			false,
 			[]Translate{
-				{Key: "key0", Language: "en", Value: "some_text", Plural: ""},
+				{Key: "key0", Language: "en", Value: "some_text", Plural: "", Line: 2},
 			},
 		},
 		{ // No errors - file exist and content matches.
@@ -194,7 +199,7 @@ func TestYAMLContent_parse(t *testing.T) {
 			true,
 			false,
 			[]Translate{
-				{Key: "key0", Language: "lv", Value: "some_text", Plural: ""},
+				{Key: "key0", Language: "lv", Value: "some_text", Plural: "", Line: 2},
 			},
 		},
 		{ // No errors - file exist and content matches.
@@ -204,8 +209,8 @@ func TestYAMLContent_parse(t *testing.T) {
 			true,
 			false,
 			[]Translate{
-				{Key: "key0", Language: "lv", Value: "some_text", Plural: ""},
-				{Key: "key0", Language: "en", Value: "some_other_text", Plural: ""},
+				{Key: "key0", Language: "lv", Value: "some_text", Plural: "", Line: 2},
+				{Key: "key0", Language: "en", Value: "some_other_text", Plural: "", Line: 3},
 			},
 		},
 		{ // No errors - file exist and content matches.
@@ -215,7 +220,7 @@ func TestYAMLContent_parse(t *testing.T) {
 			true,
 			false,
 			[]Translate{
-				{Key: "key0", Language: "lv", Value: "non_plural", Plural: "plural"},
+				{Key: "key0", Language: "lv", Value: "non_plural", Plural: "plural", Line: 2},
 			},
 		},
 		{ // No errors - file exist and content matches.
@@ -226,8 +231,8 @@ func TestYAMLContent_parse(t *testing.T) {
 			true,
 			false,
 			[]Translate{
-				{Key: "key0", Language: "lv", Value: "non_plural", Plural: "plural"},
-				{Key: "key0", Language: "en", Value: "en_non_plural", Plural: "en_plural"},
+				{Key: "key0", Language: "lv", Value: "non_plural", Plural: "plural", Line: 2},
+				{Key: "key0", Language: "en", Value: "en_non_plural", Plural: "en_plural", Line: 5},
 			},
 		},
 		{ // No error and no data - yamlContent.Data is nil
@@ -245,7 +250,7 @@ func TestYAMLContent_parse(t *testing.T) {
 			true,
 			false,
 			[]Translate{
-				{Key: "key0", Language: "lv", Value: "non_plural", Plural: ""},
+				{Key: "key0", Language: "lv", Value: "non_plural", Plural: "", Line: 2},
 			},
 		},
 
@@ -281,6 +286,44 @@ func TestYAMLContent_parse(t *testing.T) {
 			true,
 			nil,
 		},
+		{ // No errors - language keys get canonicalized.
+			"unmarshal_13.yaml",
+			"key0:\n  - pt_br: \"text\"\n  - EN-gb:\n    - \"non_plural\"\n    - \"plural\"\n",
+			"en",
+			true,
+			false,
+			[]Translate{
+				{Key: "key0", Language: "pt-BR", Value: "text", Plural: "", Line: 2},
+				{Key: "key0", Language: "en-GB", Value: "non_plural", Plural: "plural", Line: 3},
+			},
+		},
+		{ // Error - language key is not valid language tag
+			"unmarshal_14.yaml",
+			"key0:\n  - e: \"some_text\"\n",
+			"en",
+			true,
+			true,
+			nil,
+		},
+		{ // No errors - aliases are resolved, duplicate keys use the last definition.
+			"unmarshal_15.yaml",
+			"key0: &text \"text\"\nkey1:\n  - lv: *text\nkey0: \"other\"\n",
+			"en",
+			true,
+			false,
+			[]Translate{
+				{Key: "key0", Language: "en", Value: "other", Plural: "", Line: 4},
+				{Key: "key1", Language: "lv", Value: "text", Plural: "", Line: 3},
+			},
+		},
+		{ // Error - empty value
+			"unmarshal_16.yaml",
+			"key0:\n",
+			"en",
+			true,
+			true,
+			nil,
+		},
 	}
 
 	for k, v := range testCases {
@@ -1,6 +1,7 @@
 package localization
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -44,7 +45,7 @@ func TestLoadYAML(t *testing.T) {
 			false,
 			&YAMLFile{
 				FilePath: "file_0.yaml", Translates: []Translate{
-					{Key: "key0", Language: "en", Value: "text", Plural: ""},
+					{Key: "key0", Language: "en", Value: "text", Plural: "", Line: 1},
 				},
 			},
 		},
@@ -137,7 +138,7 @@ func TestLoadYAMLFiles(t *testing.T) {
 			[]*YAMLFile{
 				&YAMLFile{
 					FilePath: "file_0.yaml", Translates: []Translate{
-						{Key: "key0", Language: "en", Value: "text", Plural: ""},
+						{Key: "key0", Language: "en", Value: "text", Plural: "", Line: 1},
 					},
 				},
 			},
@@ -151,12 +152,12 @@ func TestLoadYAMLFiles(t *testing.T) {
 			[]*YAMLFile{
 				&YAMLFile{
 					FilePath: "file_1.yaml", Translates: []Translate{
-						{Key: "key0", Language: "en", Value: "text1", Plural: ""},
+						{Key: "key0", Language: "en", Value: "text1", Plural: "", Line: 1},
 					},
 				},
 				&YAMLFile{
 					FilePath: "file_2.yaml", Translates: []Translate{
-						{Key: "key1", Language: "en", Value: "text2", Plural: ""},
+						{Key: "key1", Language: "en", Value: "text2", Plural: "", Line: 1},
 					},
 				},
 			},
@@ -218,3 +219,33 @@ func TestLoadYAMLFiles(t *testing.T) {
 		}
 	}
 }
+
+func TestWithYAMLFile(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected string
+	}{
+		{&YAMLError{Kind: ErrYAMLSyntax}, "a.yml: failed to unmarshal"},
+		{fmt.Errorf("outer: %w", &YAMLError{Kind: ErrYAMLSyntax}), "a.yml: outer: failed to unmarshal"},
+		{&YAMLError{File: "b.yml", Kind: ErrYAMLSyntax}, "b.yml: failed to unmarshal"},
+		{fmt.Errorf("failed: %w", ErrYAMLSyntax), "a.yml: failed: failed to unmarshal"},
+	}
+
+	for k, v := range testCases {
+		original := v.err.Error()
+
+		err := withYAMLFile(v.err, "a.yml")
+		if err.Error() != v.expected {
+			t.Fatalf("unexpected result, index=%d, expected=%s, actual=%s", k, v.expected, err.Error())
+		}
+
+		if !errors.Is(err, ErrYAMLSyntax) {
+			t.Fatalf("expected error chain to be kept, index=%d, actual=%v", k, err)
+		}
+
+		// Passed error must not be modified.
+		if v.err.Error() != original {
+			t.Fatalf("unexpected passed error change, index=%d, expected=%s, actual=%s", k, original, v.err.Error())
+		}
+	}
+}
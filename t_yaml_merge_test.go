@@ -0,0 +1,86 @@
+package localization
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppendYAMLKeys(t *testing.T) {
+	testCases := []struct {
+		content         string
+		entries         []YAMLKeyEntry
+		expected        string
+		expectedCount   int
+		failureExpected bool
+	}{
+		{"", []YAMLKeyEntry{{"key0", []string{"en", "no"}, "main.go:3"}},
+			"# TODO main.go:3\nkey0:\n  - en: \"\"\n  - \"no\": \"\"\n", 2, false},
+		{"# Header\nkey0:\n    - en: \"Hello\" # comment\n\n# Next\nkey1: \"One\"\n", []YAMLKeyEntry{
+			{"key0", []string{"en", "lv"}, ""},
+			{"key1", []string{"lv"}, ""},
+			{"key2", []string{"lv"}, ""},
+			{"key0", []string{"de"}, ""},
+		}, "# Header\nkey0:\n    - en: \"Hello\" # comment\n    - lv: \"\"\n    - de: \"\"\n\n# Next\n" +
+			"key1:\n  - en: \"One\"\n  - lv: \"\"\n\n# TODO\nkey2:\n  - lv: \"\"\n", 4, false},
+		{"key0:\n  - en_gb:\n      - \"a\"\n      - \"b\"\nkey1: x\n", []YAMLKeyEntry{{"key0", []string{"en-GB", "lv"}, ""}},
+			"key0:\n  - en_gb:\n      - \"a\"\n      - \"b\"\n  - lv: \"\"\nkey1: x\n", 1, false},
+		{"key0:\n  - en: x\n", []YAMLKeyEntry{{"key0", []string{"en"}, ""}}, "key0:\n  - en: x\n", 0, false},
+		{"\"a:b\": x\n", []YAMLKeyEntry{{"a:b", []string{"lv"}, ""}},
+			"a:b:\n  - en: \"x\"\n  - lv: \"\"\n", 1, false},
+		{"key0: \"a\\nb\"\nkey1: c\n", []YAMLKeyEntry{{"key0", []string{"lv"}, ""}},
+			"key0:\n  - en: \"a\\nb\"\n  - lv: \"\"\nkey1: c\n", 1, false},
+		{"key0: 'It''s' # keep me\n", []YAMLKeyEntry{{"key0", []string{"lv"}, ""}},
+			"key0: # keep me\n  - en: \"It's\"\n  - lv: \"\"\n", 1, false},
+		{"key0: [{en: x}]\n", []YAMLKeyEntry{{"key0", []string{"lv"}, ""}}, "", 0, true},
+		{"- a\n", []YAMLKeyEntry{{"key0", []string{"lv"}, ""}}, "", 0, true},
+	}
+
+	for k, v := range testCases {
+		path := filepath.Join(t.TempDir(), "a.yml")
+		if v.content != "" {
+			_ = os.WriteFile(path, []byte(v.content), 0o644)
+		}
+
+		count, err := AppendYAMLKeys(path, "en", v.entries...)
+		if v.failureExpected {
+			yamlErr := &YAMLError{}
+			if !errors.As(err, &yamlErr) {
+				t.Fatalf("expected YAMLError, index=%d, actual=%v", k, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected error, index=%d: %s", k, err)
+		}
+
+		received, _ := os.ReadFile(path)
+		if string(received) != v.expected || count != v.expectedCount {
+			t.Fatalf("unexpected result, index=%d, count=%d, expected=%s, actual=%s",
+				k, count, v.expected, received)
+		}
+
+		_, err = LoadCatalog("en", path)
+		if err != nil {
+			t.Fatalf("unexpected load error, index=%d: %s", k, err)
+		}
+	}
+}
+
+func TestWriteYAMLLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.yml")
+	_ = os.WriteFile(path, []byte("key0: a\n"), 0o644)
+
+	err := writeYAMLLines(path, []string{`key0: "a:`}, true)
+	if !errors.Is(err, ErrYAMLSyntax) {
+		t.Fatalf("expected ErrYAMLSyntax, actual=%v", err)
+	}
+
+	received, _ := os.ReadFile(path)
+	if string(received) != "key0: a\n" {
+		t.Fatalf("unexpected result, expected=%s, actual=%s", "key0: a\n", received)
+	}
+}
@@ -0,0 +1,304 @@
+package localization
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template/parse"
+)
+
+// KeyRef holds information about translation key usage in source file.
+type KeyRef struct {
+	Key    string // Translation keyword/key.
+	Func   string // Function key was passed to ("Text", "t" etc).
+	File   string // Source file path or template name.
+	Line   int    // Line in source file (starting from 1).
+	Column int    // Column in source file (starting from 1).
+}
+
+// String returns key usage location and key ("page.html:3:12: t \"hello\"").
+func (r KeyRef) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s %q", r.File, r.Line, r.Column, r.Func, r.Key)
+}
+
+// TemplateIssue holds translation key used in template, which does not exist
+// in some of enabled languages.
+type TemplateIssue struct {
+	KeyRef
+	Languages []string // Languages key is missing in.
+}
+
+// String returns issue description.
+func (i TemplateIssue) String() string {
+	return fmt.Sprintf("%s missing for languages: %s", i.KeyRef, strings.Join(i.Languages, ", "))
+}
+
+// DefaultTemplateKeyFuncs returns template functions of this package mapped to
+// index of their translation key argument: Text, TextPlural, Textf,
+// TextPluralf, TextPluralIntf (locale and language come first) and
+// Localizer.FuncMap functions t, tf, tn, tnamed, trich.
+func DefaultTemplateKeyFuncs() map[string]int {
+	return map[string]int{
+		"Text":           2,
+		"TextPlural":     2,
+		"Textf":          2,
+		"TextPluralf":    2,
+		"TextPluralIntf": 2,
+		"t":              0,
+		"tf":             0,
+		"tn":             0,
+		"tnamed":         0,
+		"trich":          0,
+	}
+}
+
+// TemplateChecker can be used to find translation keys used in html/template
+// and text/template files, which do not exist in Locale languages.
+// Only literal keys are checked ({{ t "hello" }}, not {{ t .Key }}).
+// Key must exist in language itself or its parent languages ("en-GB" -> "en"),
+// other fallback languages are ignored.
+type TemplateChecker struct {
+	Locale     *Locale        // Locale with loaded translations.
+	Funcs      map[string]int // Template functions mapped to key argument index (see DefaultTemplateKeyFuncs).
+	LeftDelim  string         // Template left delimiter, "{{" if empty.
+	RightDelim string         // Template right delimiter, "}}" if empty.
+}
+
+// NewTemplateChecker constructs TemplateChecker for passed Locale with
+// DefaultTemplateKeyFuncs functions.
+func NewTemplateChecker(locale *Locale) *TemplateChecker {
+	return &TemplateChecker{Locale: locale, Funcs: DefaultTemplateKeyFuncs()}
+}
+
+// CheckGlob checks template files with given pattern (see filepath.Glob),
+// directories are skipped.
+// Returns found issues or error if something went wrong.
+func (c *TemplateChecker) CheckGlob(pattern string) ([]TemplateIssue, error) {
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("template check: failed to match pattern: %w", err)
+	}
+
+	parsedFiles := make([]string, 0, len(files))
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("template check: failed to stat file %s: %w", file, err)
+		}
+
+		if info.IsDir() {
+			continue
+		}
+
+		parsedFiles = append(parsedFiles, file)
+	}
+
+	return c.CheckFiles(parsedFiles...)
+}
+
+// CheckFiles checks passed template files.
+// Returns found issues or error if file can not be read or parsed.
+func (c *TemplateChecker) CheckFiles(filePath ...string) ([]TemplateIssue, error) {
+	issues := make([]TemplateIssue, 0)
+
+	for _, v := range filePath {
+		content, err := os.ReadFile(v)
+		if err != nil {
+			return nil, fmt.Errorf("template check: failed to read file %s: %w", v, err)
+		}
+
+		found, err := c.Check(v, string(content))
+		if err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, found...)
+	}
+
+	return issues, nil
+}
+
+// Check checks template text.
+// Returns found issues or error if template can not be parsed.
+// Params:
+// name - template name or file path (used in issues).
+// text - template text.
+func (c *TemplateChecker) Check(name, text string) ([]TemplateIssue, error) {
+	refs, err := TemplateKeys(name, text, c.LeftDelim, c.RightDelim, c.Funcs)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]TemplateIssue, 0)
+
+	for _, v := range refs {
+		languages := c.missingLanguages(v.Key)
+		if len(languages) == 0 {
+			continue
+		}
+
+		issues = append(issues, TemplateIssue{KeyRef: v, Languages: languages})
+	}
+
+	return issues, nil
+}
+
+// missingLanguages returns enabled languages, which do not contain key.
+func (c *TemplateChecker) missingLanguages(key string) []string {
+	if c.Locale == nil {
+		return nil
+	}
+
+	return c.Locale.MissingLanguages(key)
+}
+
+// TemplateKeys can be used to find literal translation keys passed to template
+// functions. Templates are parsed without function checks, so function map is
+// not required. Keys passed through pipelines ({{ "hello" | t }}) or variables
+// are not found.
+// Returns key usages sorted by position or error if template can not be parsed.
+// Params:
+// name - template name or file path.
+// text - template text.
+// leftDelim, rightDelim - template delimiters ("{{" and "}}" if empty).
+// funcs - function names mapped to key argument index (see DefaultTemplateKeyFuncs).
+func TemplateKeys(name, text, leftDelim, rightDelim string, funcs map[string]int) ([]KeyRef, error) {
+	tree := parse.New(name)
+	tree.Mode = parse.SkipFuncCheck
+
+	trees := make(map[string]*parse.Tree)
+
+	_, err := tree.Parse(text, leftDelim, rightDelim, trees)
+	if err != nil {
+		return nil, fmt.Errorf("template check: failed to parse %s: %w", name, err)
+	}
+
+	refs := make([]KeyRef, 0)
+	walker := templateWalker{name: name, text: text, funcs: funcs}
+
+	// Nested templates ({{ define }}) are parsed into separate trees.
+	for _, v := range trees {
+		if v.Root != nil {
+			refs = walker.walk(refs, v.Root)
+		}
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Line != refs[j].Line {
+			return refs[i].Line < refs[j].Line
+		}
+
+		return refs[i].Column < refs[j].Column
+	})
+
+	return refs, nil
+}
+
+// templateWalker collects key usages from template parse tree.
+type templateWalker struct {
+	name  string
+	text  string
+	funcs map[string]int
+}
+
+// walk appends key usages found in node and its children to refs.
+func (w templateWalker) walk(refs []KeyRef, node parse.Node) []KeyRef {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return refs
+		}
+
+		for _, v := range n.Nodes {
+			refs = w.walk(refs, v)
+		}
+	case *parse.ActionNode:
+		refs = w.walk(refs, n.Pipe)
+	case *parse.IfNode:
+		refs = w.walkBranch(refs, &n.BranchNode)
+	case *parse.RangeNode:
+		refs = w.walkBranch(refs, &n.BranchNode)
+	case *parse.WithNode:
+		refs = w.walkBranch(refs, &n.BranchNode)
+	case *parse.TemplateNode:
+		refs = w.walk(refs, n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return refs
+		}
+
+		for _, v := range n.Cmds {
+			refs = w.walk(refs, v)
+		}
+	case *parse.ChainNode:
+		refs = w.walk(refs, n.Node)
+	case *parse.CommandNode:
+		refs = w.walkCommand(refs, n)
+	}
+
+	return refs
+}
+
+// walkBranch appends key usages found in if, range or with node.
+func (w templateWalker) walkBranch(refs []KeyRef, n *parse.BranchNode) []KeyRef {
+	refs = w.walk(refs, n.Pipe)
+	refs = w.walk(refs, n.List)
+
+	return w.walk(refs, n.ElseList)
+}
+
+// walkCommand appends key usage if command is call of key function with
+// literal key, then walks command arguments (nested calls).
+func (w templateWalker) walkCommand(refs []KeyRef, n *parse.CommandNode) []KeyRef {
+	if len(n.Args) > 0 {
+		identifier, ok := n.Args[0].(*parse.IdentifierNode)
+		if ok {
+			refs = w.appendKey(refs, identifier.Ident, n.Args[1:])
+		}
+	}
+
+	for _, v := range n.Args {
+		refs = w.walk(refs, v)
+	}
+
+	return refs
+}
+
+// appendKey appends key usage if function is key function and its key
+// argument is string literal.
+func (w templateWalker) appendKey(refs []KeyRef, function string, args []parse.Node) []KeyRef {
+	idx, exist := w.funcs[function]
+	if !exist || idx < 0 || idx >= len(args) {
+		return refs
+	}
+
+	key, ok := args[idx].(*parse.StringNode)
+	if !ok {
+		return refs
+	}
+
+	line, column := textPosition(w.text, int(key.Position()))
+
+	return append(refs, KeyRef{
+		Key:    key.Text,
+		Func:   function,
+		File:   w.name,
+		Line:   line,
+		Column: column,
+	})
+}
+
+// textPosition converts byte offset into line and column (starting from 1).
+func textPosition(text string, offset int) (int, int) {
+	if offset > len(text) {
+		offset = len(text)
+	}
+
+	line := 1 + strings.Count(text[:offset], "\n")
+	column := offset - strings.LastIndexByte(text[:offset], '\n')
+
+	return line, column
+}
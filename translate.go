@@ -6,4 +6,5 @@ type Translate struct {
 	Language string // Language keyword ("lv", "en" etc.).
 	Value    string // Translation ("some text").
 	Plural   string // Translation in plural.
+	Line     int    // Line in YAML translate file (0 if unknown).
 }
@@ -0,0 +1,94 @@
+package localization
+
+import (
+	"fmt"
+	"path"
+	"sort"
+)
+
+// UnusedKey holds information about translation key, which is not referenced
+// in code (see Locale.UnusedKeys).
+type UnusedKey struct {
+	Key       string   // Translation keyword/key.
+	Languages []string // Languages key is defined in.
+	Sources   []Source // Unique translation sources (YAML files and lines).
+}
+
+// UnusedKeys can be used to find translation keys, which are defined in any
+// of Locale languages, but are not in used keys and do not match keep
+// patterns. Keep patterns are used for keys built at runtime ("error.*"
+// keeps "error.not_found"), pattern syntax is the same as path.Match.
+// Returns unused keys sorted by key or error if pattern is invalid.
+// Params:
+// used - referenced keys (see TemplateKeys and analyzer.Keys).
+// keep - patterns of keys which are always used.
+func (l *Locale) UnusedKeys(used []string, keep ...string) ([]UnusedKey, error) {
+	for _, v := range keep {
+		_, err := path.Match(v, "")
+		if err != nil {
+			return nil, fmt.Errorf("invalid keep pattern '%s': %w", v, err)
+		}
+	}
+
+	referenced := make(map[string]bool, len(used))
+	for _, v := range used {
+		referenced[v] = true
+	}
+
+	unused := make(map[string]*UnusedKey)
+
+	for _, language := range l.Languages {
+		for key := range language.Map {
+			if referenced[key] || matchesAny(keep, key) {
+				continue
+			}
+
+			entry, exist := unused[key]
+			if !exist {
+				entry = &UnusedKey{Key: key}
+				unused[key] = entry
+			}
+
+			entry.Languages = append(entry.Languages, language.Keyword)
+
+			source, exist := l.sources[sourceKey{language.Keyword, key}]
+			if exist && !containsSource(entry.Sources, source.File) {
+				entry.Sources = append(entry.Sources, source)
+			}
+		}
+	}
+
+	keys := make([]UnusedKey, 0, len(unused))
+	for _, v := range unused {
+		keys = append(keys, *v)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Key < keys[j].Key
+	})
+
+	return keys, nil
+}
+
+// matchesAny checks if key matches any of patterns (see path.Match).
+func matchesAny(patterns []string, key string) bool {
+	for _, v := range patterns {
+		matched, _ := path.Match(v, key)
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsSource checks if sources contain source from file.
+func containsSource(sources []Source, file string) bool {
+	for _, v := range sources {
+		if v.File == file {
+			return true
+		}
+	}
+
+	return false
+}
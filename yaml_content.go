@@ -1,11 +1,12 @@
 package localization
 
 import (
+	"errors"
 	"fmt"
-	"gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
 	"io"
 	"os"
-	"reflect"
+	"sort"
 )
 
 // yamlContent is literally holds YAML translate file content and is used
@@ -13,10 +14,10 @@ import (
 //
 // Params:
 // defaultLanguage - default language for non-list values (some_key: "value").
-// Data - unmarshalled YAML file content as map.
+// Data - unmarshalled YAML file content as mapping node (nil if content is empty).
 type yamlContent struct {
 	defaultLanguage string
-	Data            map[string]interface{}
+	Data            *yaml.Node
 }
 
 // newYAMLContent constructs new yamlContent struct with default language field.
@@ -31,15 +32,13 @@ func (c *yamlContent) loadBytes(path string) ([]byte, error) {
 	// #nosec G304
 	file, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open path '%s', error: %w",
-			path, err)
+		return nil, &YAMLError{File: path, Kind: ErrYAMLRead, Err: err}
 	}
 
 	bytes, err := io.ReadAll(file)
 	if err != nil {
 		_ = file.Close()
-		return nil, fmt.Errorf("failed to cast '%s' as bytes, error: %w",
-			path, err)
+		return nil, &YAMLError{File: path, Kind: ErrYAMLRead, Err: err}
 	}
 
 	_ = file.Close()
@@ -47,40 +46,73 @@ func (c *yamlContent) loadBytes(path string) ([]byte, error) {
 	return bytes, nil
 }
 
-// unmarshal is used to unmarshal passed YAML file bytes as a map.
-// Final results will be applied to yamlContent.Data field.
+// unmarshal is used to unmarshal passed YAML file bytes as a node tree.
+// Final results (root mapping node) will be applied to yamlContent.Data field.
 // Returns error if something went wrong.
 func (c *yamlContent) unmarshal(bytes []byte) error {
 	if bytes == nil {
-		return fmt.Errorf("unmarshal failure, bytes slice is nil")
+		return &YAMLError{Kind: ErrYAMLSyntax, Err: errors.New("bytes slice is nil")}
 	}
 
-	err := yaml.Unmarshal(bytes, &c.Data)
+	root := yaml.Node{}
+
+	err := yaml.Unmarshal(bytes, &root)
 	if err != nil {
-		return err
+		return &YAMLError{Kind: ErrYAMLSyntax, Err: err}
+	}
+
+	// Empty document or document with comments only.
+	if len(root.Content) == 0 {
+		return nil
+	}
+
+	data := resolveYAMLAlias(root.Content[0])
+	if data.Kind == yaml.ScalarNode && data.ShortTag() == "!!null" {
+		return nil
+	}
+
+	if data.Kind != yaml.MappingNode {
+		return &YAMLError{Kind: ErrYAMLSyntax, Err: fmt.Errorf("line %d: root must be mapping", data.Line)}
 	}
 
+	c.Data = data
+
 	return nil
 }
 
-// parse goes over unmarshalled map and parses content as Translate slice.
+// parse goes over unmarshalled mapping node and parses content as Translate
+// slice. Duplicate keys get value of the last definition.
 // Returns Translate slice or error if something went wrong.
 func (c *yamlContent) parse() ([]Translate, error) {
 	// If yamlContent.Data is empty then return, nothing to do.
-	if len(c.Data) == 0 {
+	if c.Data == nil || len(c.Data.Content) == 0 {
 		return nil, nil
 	}
 
 	// Create translates slice.
 	translates := make([]Translate, 0)
 
-	// Loop over all map keys.
-	for k, v := range c.Data {
-		// Get key->value reflect type and value.
-		dataType, dataValue := c.getReflectData(v)
+	// Key -> key node index (the last definition wins).
+	nodes := make(map[string]int, len(c.Data.Content)/2)
+
+	for i := 0; i+1 < len(c.Data.Content); i += 2 {
+		nodes[c.Data.Content[i].Value] = i
+	}
+
+	// Sort keys, so translates are always returned in the same order.
+	keys := make([]string, 0, len(nodes))
+	for k := range nodes {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	// Loop over all keys.
+	for _, k := range keys {
+		keyNode := c.Data.Content[nodes[k]]
 
 		// Read key->value content
-		content, err := c.readContent(k, dataType, dataValue)
+		content, err := c.readContent(k, keyNode.Line, c.Data.Content[nodes[k]+1])
 		if err != nil {
 			return nil, err
 		}
@@ -93,49 +125,42 @@ func (c *yamlContent) parse() ([]Translate, error) {
 	return translates, nil
 }
 
-// readContent reads provided map value and extracts translations by using reflection.
+// readContent reads provided key value node and extracts translations.
 // Returns Translate slice and error if something went wrong.
 //
-// Examples how YAML gets unmarshalled as map[string]interface{}:
-// key: "text" <- key:"text" (map[string]string)
+// Supported YAML values:
+// key: "text" <- string, default language
 //
 // key:
-//		- en: "text" <-- key:[map[en:"text"]] (map[string][]map[string]string)
-//
-// key:
-//		- en: "text"
-//		- lv: "other" <-- key:[map[en:"text"], map[lv:"other"]] (map[string][]map[string]string)
+//		- en: "text" <-- list of language maps
+//		- lv: "other"
 //
 // key:
 //		- en:
 // 			- "non-plural"
-//      	- "plural" <-- key:[map[en: ["non-plural", "plural"] ]] (map[string][]map[string][]string)
-func (c *yamlContent) readContent(key string, dataType reflect.Type, dataValue reflect.Value) ([]Translate, error) {
-	// Get data kind/type
-	kind := dataType.Kind()
-
-	// Is value a string then build translate and return.
-	if kind == reflect.String {
-		return []Translate{c.buildTranslateString(key, dataValue)}, nil
-	}
-
-	// Is value slice/list.
-	if kind == reflect.Slice {
-		// Extract slice values and return extracted/parsed values.
-		// buildTranslateSlice() will call this function for every slice elements
-		// as long as all values gets extracted.
-		return c.buildTranslateSlice(key, dataValue)
-	}
-
-	// Is value map.
-	if kind == reflect.Map {
+//      	- "plural" <-- language value as list of non-plural and plural
+//
+// Params:
+// key - translation key (for error messages).
+// line - translation key line (Translate.Line for one-liners).
+// node - key value node.
+func (c *yamlContent) readContent(key string, line int, node *yaml.Node) ([]Translate, error) {
+	node = resolveYAMLAlias(node)
+
+	switch {
+	case isYAMLString(node):
+		// Is value a string then build translate and return.
+		return []Translate{c.buildTranslateString(key, line, node)}, nil
+	case node.Kind == yaml.SequenceNode:
+		// Extract list values and return extracted/parsed values.
+		return c.buildTranslateSlice(key, line, node)
+	case node.Kind == yaml.MappingNode:
 		// Extract all map values and return extracted/parsed values.
-		// buildTranslateMap() will call this function for every map element as
-		// long as all map values gets extracted.
-		return c.buildTranslateMap(key, dataValue)
+		return c.buildTranslateMap(key, node)
 	}
 
-	return nil, fmt.Errorf("%s: unsupported type=%v", key, kind)
+	return nil, &YAMLError{Key: key, Kind: ErrYAMLUnsupportedValue,
+		Err: fmt.Errorf("line %d: type=%s", node.Line, node.ShortTag())}
 }
 
 // buildTranslateString takes passed translate key, string value
@@ -143,30 +168,30 @@ func (c *yamlContent) readContent(key string, dataType reflect.Type, dataValue r
 // Returns constructed Translate.
 //
 // Params:
-// key - original yamlContent.Data map key (for error messages).
-// dataValue - target string reflect.Value.
-func (c *yamlContent) buildTranslateString(key string, dataValue reflect.Value) Translate {
+// key - translation key.
+// line - translation key line.
+// node - string scalar node.
+func (c *yamlContent) buildTranslateString(key string, line int, node *yaml.Node) Translate {
 	return Translate{
 		Key:      key,
 		Language: c.defaultLanguage,
-		Value:    dataValue.String(),
+		Value:    node.Value,
+		Line:     line,
 	}
 }
 
-// buildTranslateSlice extracts slice values anc builds Translate slice from them.
+// buildTranslateSlice extracts list values anc builds Translate slice from them.
 // Returns Translate slice or error if something went wrong.
 //
 // Params:
-// key - original yamlContent.Data map key (for error messages).
-// dataValue - target slice reflect.Value.
-func (c *yamlContent) buildTranslateSlice(key string, dataValue reflect.Value) ([]Translate, error) {
-	languages := c.getSliceChilds(dataValue)
+// key - translation key (for error messages).
+// line - translation key line.
+// node - list node.
+func (c *yamlContent) buildTranslateSlice(key string, line int, node *yaml.Node) ([]Translate, error) {
 	translates := make([]Translate, 0)
 
-	for k := range languages {
-		langType := reflect.TypeOf(languages[k].Interface())
-
-		results, err := c.readContent(key, langType, languages[k])
+	for _, v := range node.Content {
+		results, err := c.readContent(key, line, v)
 		if err != nil {
 			return nil, err
 		}
@@ -181,79 +206,81 @@ func (c *yamlContent) buildTranslateSlice(key string, dataValue reflect.Value) (
 // Returns extracted Translate slice or error if something went wrong.
 //
 // Params:
-// key - original yamlContent.Data map key (for error messages).
-// dataValue - target map reflect.Value.
-func (c *yamlContent) buildTranslateMap(key string, dataValue reflect.Value) ([]Translate, error) {
+// key - translation key (for error messages).
+// node - language map node.
+func (c *yamlContent) buildTranslateMap(key string, node *yaml.Node) ([]Translate, error) {
 	translates := make([]Translate, 0)
 
-	// Get map range.
-	mapRange := dataValue.MapRange()
-
 	// Loop over all map keys.
-	for mapRange.Next() {
-		// Get map key reflect value.
-		mapKey := reflect.ValueOf(mapRange.Key().Interface())
-		// Get map value reflect value.
-		mapValue := reflect.ValueOf(mapRange.Value().Interface())
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		mapKey, mapValue := node.Content[i], resolveYAMLAlias(node.Content[i+1])
 
 		// If map key type is not string then return error.
-		// This check is required to avoid panic.
-		if mapKey.Kind() != reflect.String {
-			return nil, fmt.Errorf("'%s' > '%v' must be string", key, mapKey)
+		if !isYAMLString(mapKey) {
+			return nil, &YAMLError{Key: key, Language: mapKey.Value, Kind: ErrInvalidLanguageTag,
+				Err: errors.New("language key must be string")}
+		}
+
+		// Language keys must be valid BCP 47 language tags ("pt_BR" -> "pt-BR").
+		language, err := CanonicalTag(mapKey.Value)
+		if err != nil {
+			return nil, &YAMLError{Key: key, Language: mapKey.Value, Kind: ErrInvalidLanguageTag, Err: err}
 		}
 
 		// If map value type is string then build Translate and append it to final slice and
 		// continue with next map key.
-		if mapValue.Kind() == reflect.String {
-			translates = append(translates, Translate{Key: key, Language: mapKey.String(), Value: mapValue.String()})
+		if isYAMLString(mapValue) {
+			translates = append(translates, Translate{Key: key, Language: language, Value: mapValue.Value,
+				Line: mapKey.Line})
 			continue
 		}
 
-		// If map is not string then it MUST be slice, if not, then return error.
-		// This check is required to avoid panic.
-		if mapValue.Kind() != reflect.Slice {
-			return nil, fmt.Errorf("'%s' > '%v' value must be string or list", key, mapKey)
+		// If map is not string then it MUST be list of strings, if not, then return error.
+		if mapValue.Kind != yaml.SequenceNode {
+			return nil, &YAMLError{Key: key, Language: language, Kind: ErrYAMLUnsupportedValue,
+				Err: errors.New("value must be string or list")}
 		}
 
-		// Extract slice values (plurals in this case).
-		plurals := c.getSliceChilds(mapValue)
+		// Extract list values (plurals in this case).
+		plurals := mapValue.Content
 
 		if len(plurals) > 2 {
-			return nil, fmt.Errorf("'%s' > '%v': contains more than 2 plural entries", key, mapKey)
+			return nil, &YAMLError{Key: key, Language: language, Kind: ErrYAMLTooManyPlurals}
 		}
 
-		if len(plurals) == 1 {
-			// Build translate from extracted plurals and append it to final slice.
-			translates = append(translates, Translate{Key: key, Language: mapKey.String(), Value: plurals[0].String()})
-			continue
+		translate := Translate{Key: key, Language: language, Line: mapKey.Line}
+
+		for k, v := range plurals {
+			v = resolveYAMLAlias(v)
+			if !isYAMLString(v) {
+				return nil, &YAMLError{Key: key, Language: language, Kind: ErrYAMLUnsupportedValue,
+					Err: errors.New("plural list values must be strings")}
+			}
+
+			if k == 0 {
+				translate.Value = v.Value
+			} else {
+				translate.Plural = v.Value
+			}
 		}
 
-		// Build translate from extracted plurals and append it to final slice.
-		translates = append(translates, Translate{Key: key, Language: mapKey.String(), Value: plurals[0].String(),
-			Plural: plurals[1].String()})
+		translates = append(translates, translate)
 	}
 
 	return translates, nil
 }
 
-// getSliceChilds extract slice elements.
-// Returns slice elements reflect values as slice or error if something went wrong.
-func (c *yamlContent) getSliceChilds(dataValue reflect.Value) []reflect.Value {
-	// Get length of slice.
-	elementCount := dataValue.Len()
-	// Create slice for each element.
-	arr := make([]reflect.Value, elementCount)
-
-	// Get reflect value for each element and apply it to the final slice.
-	for k := range arr {
-		arr[k] = reflect.ValueOf(dataValue.Index(k).Interface())
+// resolveYAMLAlias returns node referenced by alias node ("*anchor"), other
+// nodes are returned as is.
+func resolveYAMLAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
 	}
 
-	// Return results.
-	return arr
+	return node
 }
 
-// getReflectData is helper method for casting passed data interface as reflect type and value.
-func (c *yamlContent) getReflectData(data interface{}) (reflect.Type, reflect.Value) {
-	return reflect.TypeOf(data), reflect.ValueOf(data)
+// isYAMLString checks if node is string scalar ("text", text).
+func isYAMLString(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!str"
 }
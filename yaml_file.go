@@ -1,6 +1,7 @@
 package localization
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -31,7 +32,7 @@ func loadYAML(defaultLanguage, path string) (*YAMLFile, error) {
 	// Unmarshal file content.
 	err = content.unmarshal(bytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal '%s': %w", path, err)
+		return nil, withYAMLFile(err, path)
 	}
 
 	// Apply file path for YAMLFile struct (for better error messages).
@@ -40,13 +41,37 @@ func loadYAML(defaultLanguage, path string) (*YAMLFile, error) {
 	// Parse YAML content.
 	yamlFile.Translates, err = content.parse()
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", path, err)
+		return nil, withYAMLFile(err, path)
 	}
 
 	// Return parsed YAML file.
 	return &yamlFile, nil
 }
 
+// withYAMLFile applies file path to error. YAMLError without file path gets
+// copied with path set, other errors (including wrapped YAMLError) get wrapped
+// with path, errors which already have path are returned as is. Passed error
+// is never modified.
+func withYAMLFile(err error, path string) error {
+	yamlErr := &YAMLError{}
+	if !errors.As(err, &yamlErr) {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if yamlErr.File != "" {
+		return err
+	}
+
+	if error(yamlErr) != err {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	withFile := *yamlErr
+	withFile.File = path
+
+	return &withFile
+}
+
 // LoadYAMLFiles can be used to load and parse one or more YAML files with
 // containing translations.
 // Returns []*YAMLFile or error if something went wrong.
@@ -0,0 +1,414 @@
+package localization
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+)
+
+// YAMLKeyEntry describes translation key added to YAML translate file by
+// AppendYAMLKeys.
+type YAMLKeyEntry struct {
+	Key       string   // Translation keyword/key.
+	Languages []string // Languages to add empty values for.
+	Comment   string   // Comment written after "TODO" for new keys (usage location), optional.
+}
+
+// AppendYAMLKeys can be used to add translation keys with empty values to YAML
+// translate file, keeping existing content (translations, comments and
+// formatting) untouched. Keys already defined in file get empty entries for
+// passed languages they do not have yet (one-liners are converted to list
+// form), other keys are appended at the end of file with "# TODO" comment.
+// File is created if it does not exist.
+// Returns count of added language entries or error if something went wrong.
+//
+// Params:
+// path - YAML file path.
+// defaultLanguage - default language for non-list values (some_key: "value").
+// entries - keys to add.
+func AppendYAMLKeys(path, defaultLanguage string, entries ...YAMLKeyEntry) (int, error) {
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return 0, &YAMLError{File: path, Kind: ErrYAMLRead, Err: err}
+	}
+
+	lines := strings.Split(string(content), "\n")
+	if len(content) == 0 {
+		lines = nil
+	}
+
+	blocks, err := yamlKeyBlocks(content, len(lines))
+	if err != nil {
+		return 0, withYAMLFile(err, path)
+	}
+
+	entries = mergeYAMLKeyEntries(entries)
+	existing := make([]YAMLKeyEntry, 0)
+
+	for _, v := range entries {
+		if _, exist := blocks[v.Key]; exist {
+			existing = append(existing, v)
+		}
+	}
+
+	// Existing keys are processed from the end of file, so line indexes of
+	// blocks before them stay valid.
+	sort.Slice(existing, func(i, j int) bool {
+		return blocks[existing[i].Key].start > blocks[existing[j].Key].start
+	})
+
+	added := 0
+	appended := make([]string, 0)
+
+	for _, v := range existing {
+		var count int
+
+		lines, count, err = blocks[v.Key].insert(lines, defaultLanguage, v.Languages)
+		if err != nil {
+			return 0, withYAMLFile(err, path)
+		}
+
+		added += count
+	}
+
+	for _, v := range entries {
+		if _, exist := blocks[v.Key]; exist || len(v.Languages) == 0 {
+			continue
+		}
+
+		appended = append(appended, "", "# TODO"+yamlComment(v.Comment), yamlScalar(v.Key)+":")
+
+		for _, language := range v.Languages {
+			appended = append(appended, "  - "+yamlScalar(language)+`: ""`)
+		}
+
+		added += len(v.Languages)
+	}
+
+	if added == 0 {
+		return 0, nil
+	}
+
+	// Keep single trailing new line.
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 && len(appended) > 0 {
+		appended = appended[1:]
+	}
+
+	lines = append(lines, appended...)
+
+	err = writeYAMLLines(path, lines, true)
+	if err != nil {
+		return 0, err
+	}
+
+	return added, nil
+}
+
+// RemoveYAMLKeys can be used to remove translation keys from YAML translate
+// file, keeping other content untouched. Head comment of removed key (comment
+// lines directly above it, except file header) is removed too.
+// Returns count of removed keys or error if something went wrong.
+//
+// Params:
+// path - YAML file path.
+// keys - translation keys to remove.
+func RemoveYAMLKeys(path string, keys ...string) (int, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, &YAMLError{File: path, Kind: ErrYAMLRead, Err: err}
+	}
+
+	lines := strings.Split(string(content), "\n")
+
+	blocks, err := yamlKeyBlocks(content, len(lines))
+	if err != nil {
+		return 0, withYAMLFile(err, path)
+	}
+
+	removed := make([]yamlKeyBlock, 0, len(keys))
+
+	for _, v := range keys {
+		block, exist := blocks[v]
+		if exist {
+			removed = append(removed, block)
+			delete(blocks, v)
+		}
+	}
+
+	if len(removed) == 0 {
+		return 0, nil
+	}
+
+	// Blocks are removed from the end of file, so line indexes of blocks
+	// before them stay valid.
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].start > removed[j].start
+	})
+
+	for _, v := range removed {
+		start, end := v.start, v.end
+
+		// Only head comment of key itself is removed. Comment at the beginning
+		// of file is kept as file header even if it is attached to key.
+		comments := v.comments
+		if start == comments {
+			comments = 0
+		}
+
+		for ; comments > 0 && start > 0 && strings.HasPrefix(lines[start-1], "#"); comments-- {
+			start--
+		}
+
+		// Avoid double empty lines left in place of removed block.
+		if end+1 < len(lines) && strings.TrimSpace(lines[end+1]) == "" &&
+			(start == 0 || strings.TrimSpace(lines[start-1]) == "") {
+			end++
+		}
+
+		lines = append(lines[:start], lines[end+1:]...)
+	}
+
+	err = writeYAMLLines(path, lines, false)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(removed), nil
+}
+
+// writeYAMLLines writes lines into YAML file (with trailing new line if
+// newLine is true). Content is parsed before writing, so file never gets
+// replaced with invalid YAML.
+// Returns error if content is not valid YAML mapping or write fails.
+func writeYAMLLines(path string, lines []string, newLine bool) error {
+	content := strings.Join(lines, "\n")
+	if newLine {
+		content += "\n"
+	}
+
+	_, err := yamlKeyBlocks([]byte(content), len(lines))
+	if err != nil {
+		return withYAMLFile(err, path)
+	}
+
+	err = os.WriteFile(path, []byte(content), 0o644)
+	if err != nil {
+		return fmt.Errorf("%s: failed to write file: %w", path, err)
+	}
+
+	return nil
+}
+
+// yamlKeyBlock holds location of top-level translation key in YAML file lines.
+type yamlKeyBlock struct {
+	key       string     // Translation keyword/key.
+	node      *yaml.Node // Key value node.
+	start     int        // Index of key line.
+	comments  int        // Count of key head comment lines.
+	end       int        // Index of the last content line of block.
+	languages []string   // Defined languages (canonical keywords), empty for one-liners.
+}
+
+// yamlKeyBlocks finds top-level translation keys in YAML file content.
+// Duplicate keys get location of the last definition (the one used by parser).
+// Returns blocks by key or error if content is not valid YAML mapping.
+func yamlKeyBlocks(content []byte, total int) (map[string]yamlKeyBlock, error) {
+	blocks := make(map[string]yamlKeyBlock)
+
+	root := yaml.Node{}
+
+	err := yaml.Unmarshal(content, &root)
+	if err != nil {
+		return nil, &YAMLError{Kind: ErrYAMLSyntax, Err: err}
+	}
+
+	if len(root.Content) == 0 {
+		return blocks, nil
+	}
+
+	mapping := root.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, &YAMLError{Kind: ErrYAMLSyntax, Err: errors.New("root must be mapping")}
+	}
+
+	lines := strings.Split(string(content), "\n")
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		end := total - 1
+		if i+2 < len(mapping.Content) {
+			end = mapping.Content[i+2].Line - 2
+		}
+
+		// Skip trailing empty and top-level comment lines (they belong to the
+		// next key), indented comments belong to the block.
+		start := mapping.Content[i].Line - 1
+		for end > start && isYAMLBlank(lines[end]) {
+			end--
+		}
+
+		block := yamlKeyBlock{key: mapping.Content[i].Value, node: mapping.Content[i+1], start: start, end: end}
+		if mapping.Content[i].HeadComment != "" {
+			block.comments = strings.Count(mapping.Content[i].HeadComment, "\n") + 1
+		}
+		block.languages = yamlBlockLanguages(block.node)
+
+		blocks[mapping.Content[i].Value] = block
+	}
+
+	return blocks, nil
+}
+
+// insert adds empty entries for languages block does not contain yet.
+// Returns updated lines, count of added entries or error if block value has
+// unsupported form.
+func (b yamlKeyBlock) insert(lines []string, defaultLanguage string, languages []string) ([]string, int, error) {
+	defined := b.languages
+	if b.node.Kind == yaml.ScalarNode {
+		defined = []string{normalizeKeyword(defaultLanguage)}
+	}
+
+	missing := make([]string, 0, len(languages))
+
+	for _, v := range languages {
+		keyword, err := CanonicalTag(v)
+		if err != nil {
+			keyword = v
+		}
+
+		if !containsString(defined, keyword) && !containsString(missing, v) {
+			missing = append(missing, v)
+		}
+	}
+
+	if len(missing) == 0 {
+		return lines, 0, nil
+	}
+
+	entries := make([]string, 0, len(missing)+2)
+
+	switch {
+	case b.node.Kind == yaml.SequenceNode && b.node.Style&yaml.FlowStyle == 0 && len(b.node.Content) > 0:
+		indent := strings.Repeat(" ", b.node.Column-1)
+
+		for _, v := range missing {
+			entries = append(entries, indent+"- "+yamlScalar(v)+`: ""`)
+		}
+	case b.node.Kind == yaml.ScalarNode && b.start == b.node.Line-1 && b.start == b.end:
+		// One-liner (key: "value") gets converted to list form, value is
+		// written as double-quoted scalar, so multi-line values stay valid.
+		entries = append(entries, yamlScalar(b.key)+":"+yamlComment(b.node.LineComment),
+			"  - "+yamlScalar(defaultLanguage)+": "+yamlQuoted(b.node.Value))
+
+		for _, v := range missing {
+			entries = append(entries, "  - "+yamlScalar(v)+`: ""`)
+		}
+
+		lines = append(lines[:b.start:b.start], lines[b.start+1:]...)
+		b.end--
+	default:
+		return nil, 0, &YAMLError{Key: b.key, Kind: ErrYAMLUnsupportedValue,
+			Err: fmt.Errorf("can not add languages to value at line %d", b.node.Line)}
+	}
+
+	result := make([]string, 0, len(lines)+len(entries))
+	result = append(result, lines[:b.end+1]...)
+	result = append(result, entries...)
+	result = append(result, lines[b.end+1:]...)
+
+	return result, len(missing), nil
+}
+
+// mergeYAMLKeyEntries merges languages of entries with the same key.
+// Returns unique entries in order of the first appearance.
+func mergeYAMLKeyEntries(entries []YAMLKeyEntry) []YAMLKeyEntry {
+	merged := make([]YAMLKeyEntry, 0, len(entries))
+	indexes := make(map[string]int, len(entries))
+
+	for _, v := range entries {
+		idx, exist := indexes[v.Key]
+		if !exist {
+			indexes[v.Key] = len(merged)
+			merged = append(merged, YAMLKeyEntry{Key: v.Key, Languages: append([]string(nil), v.Languages...), Comment: v.Comment})
+
+			continue
+		}
+
+		for _, language := range v.Languages {
+			if !containsString(merged[idx].Languages, language) {
+				merged[idx].Languages = append(merged[idx].Languages, language)
+			}
+		}
+	}
+
+	return merged
+}
+
+// yamlBlockLanguages returns canonical keywords of languages defined in key
+// value node, nil for one-liners.
+func yamlBlockLanguages(node *yaml.Node) []string {
+	languages := make([]string, 0)
+
+	if node.Kind != yaml.SequenceNode {
+		return languages
+	}
+
+	for _, v := range node.Content {
+		if v.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for i := 0; i < len(v.Content); i += 2 {
+			keyword, err := CanonicalTag(v.Content[i].Value)
+			if err != nil {
+				keyword = v.Content[i].Value
+			}
+
+			languages = append(languages, keyword)
+		}
+	}
+
+	return languages
+}
+
+// yamlScalar returns value as YAML scalar, quoted if required ("no" -> "\"no\"").
+func yamlScalar(value string) string {
+	bytes, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%q", value)
+	}
+
+	return strings.TrimSuffix(string(bytes), "\n")
+}
+
+// yamlQuoted returns value as double-quoted YAML scalar ("a\nb" -> "\"a\\nb\"").
+func yamlQuoted(value string) string {
+	bytes, err := yaml.Marshal(&yaml.Node{Kind: yaml.ScalarNode, Style: yaml.DoubleQuotedStyle, Value: value})
+	if err != nil {
+		return fmt.Sprintf("%q", value)
+	}
+
+	return strings.TrimSuffix(string(bytes), "\n")
+}
+
+// yamlComment returns comment text with leading space, empty if comment is empty.
+func yamlComment(comment string) string {
+	comment = strings.ReplaceAll(comment, "\n", " ")
+	if comment == "" {
+		return ""
+	}
+
+	return " " + comment
+}
+
+// isYAMLBlank checks if line is empty or contains only top-level comment.
+func isYAMLBlank(line string) bool {
+	return strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#")
+}